@@ -0,0 +1,263 @@
+// Package action maps named actions and axes to rebindable keys, mouse buttons
+// and joystick inputs.
+//
+// Programs query actions by name, such as "jump" or "move", instead of
+// hardcoding keys, which lets users remap their controls. The bindings are
+// saved to and loaded from JSON; e.g.
+//
+//	{
+//		"actions": {
+//			"jump": ["Space", "Joystick0 Button0"]
+//		},
+//		"axes": {
+//			"move": ["A/D", "Left/Right", "Joystick0 X"]
+//		}
+//	}
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/mewspring/sfml/window"
+)
+
+// A Map maps named actions and axes to their bindings, and tracks the state of
+// the actions from frame to frame. The zero value is an action map without
+// bindings and without a dead zone; NewMap returns one with the default dead
+// zone.
+type Map struct {
+	// Dead zone of joystick axes, in the range [0, 1]; joystick positions
+	// within the dead zone are reported as 0 by Axis.
+	DeadZone float64
+	// Bindings of the actions.
+	actions map[string][]Binding
+	// Bindings of the axes.
+	axes map[string][]AxisBinding
+	// Actions held down during the current and the previous frame.
+	down, prev map[string]bool
+	// Actions with a binding pressed and released during the current frame,
+	// respectively.
+	pressed, released map[string]bool
+	// Values of the axes during the current frame.
+	values map[string]float64
+}
+
+// NewMap returns a new action map without bindings.
+func NewMap() *Map {
+	return &Map{
+		DeadZone: 0.2,
+		actions:  make(map[string][]Binding),
+		axes:     make(map[string][]AxisBinding),
+		down:     make(map[string]bool),
+		prev:     make(map[string]bool),
+		pressed:  make(map[string]bool),
+		released: make(map[string]bool),
+		values:   make(map[string]float64),
+	}
+}
+
+// init initializes the maps of a zero value action map.
+func (m *Map) init() {
+	if m.actions == nil {
+		m.actions = make(map[string][]Binding)
+	}
+	if m.axes == nil {
+		m.axes = make(map[string][]AxisBinding)
+	}
+	if m.down != nil {
+		return
+	}
+	m.down = make(map[string]bool)
+	m.prev = make(map[string]bool)
+	m.pressed = make(map[string]bool)
+	m.released = make(map[string]bool)
+	m.values = make(map[string]float64)
+}
+
+// Bind binds the given action to the provided bindings, replacing any existing
+// bindings of the action. An action without bindings is removed.
+func (m *Map) Bind(action string, bindings ...Binding) {
+	m.init()
+	if len(bindings) == 0 {
+		delete(m.actions, action)
+		return
+	}
+	m.actions[action] = append([]Binding(nil), bindings...)
+}
+
+// Bindings returns the bindings of the given action.
+func (m *Map) Bindings(action string) []Binding {
+	return append([]Binding(nil), m.actions[action]...)
+}
+
+// Actions returns the names of the bound actions, in sorted order.
+func (m *Map) Actions() []string {
+	var names []string
+	for name := range m.actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BindAxis binds the given axis to the provided axis bindings, replacing any
+// existing bindings of the axis. An axis without bindings is removed.
+func (m *Map) BindAxis(axis string, bindings ...AxisBinding) {
+	m.init()
+	if len(bindings) == 0 {
+		delete(m.axes, axis)
+		return
+	}
+	m.axes[axis] = append([]AxisBinding(nil), bindings...)
+}
+
+// AxisBindings returns the bindings of the given axis.
+func (m *Map) AxisBindings(axis string) []AxisBinding {
+	return append([]AxisBinding(nil), m.axes[axis]...)
+}
+
+// Axes returns the names of the bound axes, in sorted order.
+func (m *Map) Axes() []string {
+	var names []string
+	for name := range m.axes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Update updates the state of the actions and axes based on the provided input
+// state. It should be called once per frame, after the events of the frame
+// have been passed to the input state.
+func (m *Map) Update(in *window.Input) {
+	m.init()
+	m.prev, m.down = m.down, m.prev
+	for action := range m.down {
+		delete(m.down, action)
+	}
+	for action := range m.pressed {
+		delete(m.pressed, action)
+	}
+	for action := range m.released {
+		delete(m.released, action)
+	}
+	for action, bindings := range m.actions {
+		for _, b := range bindings {
+			if b.down(in) {
+				m.down[action] = true
+			}
+			if b.justPressed(in) {
+				m.pressed[action] = true
+			}
+			if b.justReleased(in) {
+				m.released[action] = true
+			}
+		}
+	}
+	for axis := range m.values {
+		delete(m.values, axis)
+	}
+	for axis, bindings := range m.axes {
+		// Use the binding which is pushed the furthest.
+		v := 0.0
+		for _, b := range bindings {
+			if bv := b.value(in, m.DeadZone); math.Abs(bv) > math.Abs(v) {
+				v = bv
+			}
+		}
+		m.values[axis] = v
+	}
+}
+
+// Down reports whether any binding of the given action is held down.
+func (m *Map) Down(action string) bool {
+	return m.down[action]
+}
+
+// JustPressed reports whether the given action was pressed during the current
+// frame, including taps which were pressed and released within the frame.
+func (m *Map) JustPressed(action string) bool {
+	if m.prev[action] {
+		return false
+	}
+	return m.down[action] || m.pressed[action]
+}
+
+// JustReleased reports whether the given action was released during the
+// current frame, including taps which were pressed and released within the
+// frame.
+func (m *Map) JustReleased(action string) bool {
+	if m.down[action] {
+		return false
+	}
+	return m.prev[action] || m.released[action]
+}
+
+// Axis returns the value of the given axis, in the range [-1, 1].
+func (m *Map) Axis(axis string) float64 {
+	return m.values[axis]
+}
+
+// jsonMap is the JSON representation of the bindings of an action map.
+type jsonMap struct {
+	// Bindings of the actions.
+	Actions map[string][]Binding `json:"actions,omitempty"`
+	// Bindings of the axes.
+	Axes map[string][]AxisBinding `json:"axes,omitempty"`
+}
+
+// MarshalJSON encodes the bindings of the action map in JSON format.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	v := jsonMap{
+		Actions: m.actions,
+		Axes:    m.axes,
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes the bindings of the action map from JSON format,
+// replacing all existing bindings.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	var v jsonMap
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Actions == nil {
+		v.Actions = make(map[string][]Binding)
+	}
+	if v.Axes == nil {
+		v.Axes = make(map[string][]AxisBinding)
+	}
+	m.actions = v.Actions
+	m.axes = v.Axes
+	return nil
+}
+
+// Save saves the bindings of the action map to the provided JSON file.
+func (m *Map) Save(path string) error {
+	buf, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return fmt.Errorf("Map.Save: %v", err)
+	}
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		return fmt.Errorf("Map.Save: %v", err)
+	}
+	return nil
+}
+
+// Load loads the bindings of the action map from the provided JSON file,
+// replacing all existing bindings.
+func (m *Map) Load(path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Map.Load: %v", err)
+	}
+	if err := json.Unmarshal(buf, m); err != nil {
+		return fmt.Errorf("Map.Load: unable to decode %q; %v", path, err)
+	}
+	return nil
+}
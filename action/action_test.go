@@ -0,0 +1,123 @@
+package action
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/sfml/window"
+	"github.com/mewspring/we"
+)
+
+func TestMapJSON(t *testing.T) {
+	m := NewMap()
+	m.Bind("jump", Key(we.KeySpace), JoystickButton(0, 0))
+	m.Bind("fire", Mouse(we.ButtonLeft), Key(we.Key(1000)), JoystickAxis(0, joystick.Z, 1))
+	m.BindAxis("move", Pair(Key(we.KeyA), Key(we.KeyD)), Pair(Key(we.KeyLeft), Key(we.KeyRight)), Stick(0, joystick.X))
+	buf, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error; %v", err)
+	}
+	got := NewMap()
+	got.Bind("stale", Key(we.KeyEscape))
+	if err := json.Unmarshal(buf, got); err != nil {
+		t.Fatalf("unable to decode %s; %v", buf, err)
+	}
+	if want, names := m.Actions(), got.Actions(); !reflect.DeepEqual(names, want) {
+		t.Errorf("actions mismatch; expected %q, got %q", want, names)
+	}
+	for _, action := range m.Actions() {
+		if want, bindings := m.Bindings(action), got.Bindings(action); !reflect.DeepEqual(bindings, want) {
+			t.Errorf("action %q: bindings mismatch; expected %v, got %v", action, want, bindings)
+		}
+	}
+	if want, names := m.Axes(), got.Axes(); !reflect.DeepEqual(names, want) {
+		t.Errorf("axes mismatch; expected %q, got %q", want, names)
+	}
+	for _, axis := range m.Axes() {
+		if want, bindings := m.AxisBindings(axis), got.AxisBindings(axis); !reflect.DeepEqual(bindings, want) {
+			t.Errorf("axis %q: bindings mismatch; expected %v, got %v", axis, want, bindings)
+		}
+	}
+}
+
+func TestMapJustPressed(t *testing.T) {
+	m := NewMap()
+	m.Bind("jump", Key(we.KeySpace), Mouse(we.ButtonLeft))
+	in := window.NewInput()
+	golden := []struct {
+		// Events of the frame.
+		events []we.Event
+		// Expected state of the action.
+		down, pressed, released bool
+	}{
+		// Idle.
+		{},
+		// Tap within a single frame.
+		{
+			events:   []we.Event{we.KeyPress{Key: we.KeySpace}, we.KeyRelease{Key: we.KeySpace}},
+			pressed:  true,
+			released: true,
+		},
+		// Press.
+		{
+			events:  []we.Event{we.KeyPress{Key: we.KeySpace}},
+			down:    true,
+			pressed: true,
+		},
+		// Press of another binding while held.
+		{
+			events: []we.Event{we.MousePress{Button: we.ButtonLeft}},
+			down:   true,
+		},
+		// Release of one of the held bindings.
+		{
+			events: []we.Event{we.KeyRelease{Key: we.KeySpace}},
+			down:   true,
+		},
+		// Release.
+		{
+			events:   []we.Event{we.MouseRelease{Button: we.ButtonLeft}},
+			released: true,
+		},
+	}
+	for frame, g := range golden {
+		for _, e := range g.events {
+			in.Update(e)
+		}
+		m.Update(in)
+		if down := m.Down("jump"); down != g.down {
+			t.Errorf("frame %d: Down mismatch; expected %v, got %v", frame, g.down, down)
+		}
+		if pressed := m.JustPressed("jump"); pressed != g.pressed {
+			t.Errorf("frame %d: JustPressed mismatch; expected %v, got %v", frame, g.pressed, pressed)
+		}
+		if released := m.JustReleased("jump"); released != g.released {
+			t.Errorf("frame %d: JustReleased mismatch; expected %v, got %v", frame, g.released, released)
+		}
+		in.Advance()
+	}
+}
+
+func TestMapZero(t *testing.T) {
+	in := window.NewInput()
+	in.Update(we.KeyPress{Key: we.KeySpace})
+	// Bindings of a zero value action map.
+	var m Map
+	m.Bind("jump", Key(we.KeySpace))
+	m.BindAxis("move", Pair(Key(we.KeyA), Key(we.KeyD)))
+	m.Update(in)
+	if !m.Down("jump") {
+		t.Errorf("bound map: Down mismatch; expected true, got false")
+	}
+	// Bindings decoded into a zero value action map.
+	var got Map
+	if err := json.Unmarshal([]byte(`{"actions": {"jump": ["Space"]}}`), &got); err != nil {
+		t.Fatalf("unable to decode bindings; %v", err)
+	}
+	got.Update(in)
+	if !got.Down("jump") {
+		t.Errorf("decoded map: Down mismatch; expected true, got false")
+	}
+}
@@ -0,0 +1,344 @@
+package action
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/sfml/window"
+	"github.com/mewspring/we"
+)
+
+// Kind specifies the kind of input of a binding.
+type Kind int
+
+// Binding kinds.
+const (
+	// KindKey is a key of the keyboard.
+	KindKey Kind = iota + 1
+	// KindMouse is a mouse button.
+	KindMouse
+	// KindJoystickButton is a joystick button.
+	KindJoystickButton
+	// KindJoystickAxis is a joystick axis, or one direction of it.
+	KindJoystickAxis
+)
+
+// A Binding binds an action to a key, a mouse button, a joystick button or one
+// direction of a joystick axis.
+//
+// The text representation of a binding is human-readable, as suitable for a
+// rebinding user interface; e.g.
+//
+//	Space
+//	Mouse Left
+//	Joystick0 Button3
+//	Joystick0 X+
+type Binding struct {
+	// Kind of the binding.
+	Kind Kind
+	// Key of the binding, for KindKey.
+	Key we.Key
+	// Mouse button of the binding, for KindMouse.
+	Button we.Button
+	// Index of the joystick, for KindJoystickButton and KindJoystickAxis.
+	Joystick int
+	// Index of the joystick button, for KindJoystickButton.
+	JoystickButton int
+	// Joystick axis of the binding, for KindJoystickAxis.
+	Axis joystick.Axis
+	// Direction of the joystick axis; positive (1), negative (-1) or both (0).
+	// Both directions are only valid within axis bindings.
+	Dir int
+}
+
+// Key returns a binding of the given key.
+func Key(key we.Key) Binding {
+	return Binding{Kind: KindKey, Key: key}
+}
+
+// Mouse returns a binding of the given mouse button.
+func Mouse(button we.Button) Binding {
+	return Binding{Kind: KindMouse, Button: button}
+}
+
+// JoystickButton returns a binding of the given button of the specified
+// joystick.
+func JoystickButton(id, button int) Binding {
+	return Binding{Kind: KindJoystickButton, Joystick: id, JoystickButton: button}
+}
+
+// JoystickAxis returns a binding of the given direction of an axis of the
+// specified joystick; positive (1) or negative (-1).
+func JoystickAxis(id int, axis joystick.Axis, dir int) Binding {
+	return Binding{Kind: KindJoystickAxis, Joystick: id, Axis: axis, Dir: dir}
+}
+
+// mouseNames maps from mouse buttons to their human-readable names.
+var mouseNames = map[we.Button]string{
+	we.ButtonLeft:   "Left",
+	we.ButtonRight:  "Right",
+	we.ButtonMiddle: "Middle",
+	we.Button4:      "Button4",
+	we.Button5:      "Button5",
+}
+
+// String returns the human-readable representation of the binding.
+func (b Binding) String() string {
+	switch b.Kind {
+	case KindKey:
+		return KeyName(b.Key)
+	case KindMouse:
+		if name, ok := mouseNames[b.Button]; ok {
+			return "Mouse " + name
+		}
+		return fmt.Sprintf("Mouse Button%d", int(b.Button))
+	case KindJoystickButton:
+		return fmt.Sprintf("Joystick%d Button%d", b.Joystick, b.JoystickButton)
+	case KindJoystickAxis:
+		s := fmt.Sprintf("Joystick%d %v", b.Joystick, b.Axis)
+		switch {
+		case b.Dir > 0:
+			s += "+"
+		case b.Dir < 0:
+			s += "-"
+		}
+		return s
+	}
+	return "None"
+}
+
+// ParseBinding parses the provided human-readable representation of a binding.
+func ParseBinding(s string) (Binding, error) {
+	b, err := parseBinding(s)
+	if err != nil {
+		return Binding{}, fmt.Errorf("action.ParseBinding: %v", err)
+	}
+	if b.Kind == KindJoystickAxis && b.Dir == 0 {
+		return Binding{}, fmt.Errorf("action.ParseBinding: missing direction of joystick axis %q; expected '+' or '-' suffix", s)
+	}
+	return b, nil
+}
+
+// parseBinding parses the provided human-readable representation of a binding,
+// which may refer to both directions of a joystick axis.
+func parseBinding(s string) (Binding, error) {
+	fields := strings.Fields(s)
+	switch {
+	case len(fields) == 1:
+		key, ok := ParseKey(fields[0])
+		if !ok {
+			return Binding{}, fmt.Errorf("invalid key name %q", fields[0])
+		}
+		return Key(key), nil
+	case len(fields) == 2 && fields[0] == "Mouse":
+		for button, name := range mouseNames {
+			if name == fields[1] {
+				return Mouse(button), nil
+			}
+		}
+		// Mouse buttons without a name, as formatted by String.
+		if strings.HasPrefix(fields[1], "Button") {
+			button, err := strconv.ParseUint(strings.TrimPrefix(fields[1], "Button"), 10, 0)
+			if err != nil {
+				return Binding{}, fmt.Errorf("invalid mouse button of %q; %v", s, err)
+			}
+			return Mouse(we.Button(button)), nil
+		}
+		return Binding{}, fmt.Errorf("invalid mouse button %q", fields[1])
+	case len(fields) == 2 && strings.HasPrefix(fields[0], "Joystick"):
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[0], "Joystick"))
+		if err != nil {
+			return Binding{}, fmt.Errorf("invalid joystick index of %q; %v", s, err)
+		}
+		if strings.HasPrefix(fields[1], "Button") {
+			button, err := strconv.Atoi(strings.TrimPrefix(fields[1], "Button"))
+			if err != nil {
+				return Binding{}, fmt.Errorf("invalid joystick button of %q; %v", s, err)
+			}
+			return JoystickButton(id, button), nil
+		}
+		name, dir := fields[1], 0
+		switch {
+		case strings.HasSuffix(name, "+"):
+			name, dir = name[:len(name)-1], 1
+		case strings.HasSuffix(name, "-"):
+			name, dir = name[:len(name)-1], -1
+		}
+		for axis := joystick.Axis(0); axis < joystick.MaxAxes; axis++ {
+			if axis.String() == name {
+				return JoystickAxis(id, axis, dir), nil
+			}
+		}
+		return Binding{}, fmt.Errorf("invalid joystick axis %q", name)
+	}
+	return Binding{}, fmt.Errorf("invalid binding %q", s)
+}
+
+// MarshalText encodes the binding in its human-readable representation.
+func (b Binding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes the binding from its human-readable representation.
+func (b *Binding) UnmarshalText(text []byte) error {
+	v, err := ParseBinding(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// down reports whether the input of the binding is held down, based on the
+// provided input state.
+func (b Binding) down(in *window.Input) bool {
+	switch b.Kind {
+	case KindKey:
+		return in.Down(b.Key)
+	case KindMouse:
+		return in.ButtonDown(b.Button)
+	case KindJoystickButton:
+		return in.JoystickDown(b.Joystick, b.JoystickButton)
+	case KindJoystickAxis:
+		// Joystick axes act as buttons when pushed halfway in the direction of
+		// the binding.
+		return in.JoystickPos(b.Joystick, b.Axis)*float64(b.Dir) > 50
+	}
+	return false
+}
+
+// justPressed reports whether the binding was pressed since the last frame,
+// even if it was released again. Joystick axes are never reported as just
+// pressed, as their positions are not tracked within a frame.
+func (b Binding) justPressed(in *window.Input) bool {
+	switch b.Kind {
+	case KindKey:
+		return in.JustPressed(b.Key)
+	case KindMouse:
+		return in.ButtonJustPressed(b.Button)
+	case KindJoystickButton:
+		return in.JoystickJustPressed(b.Joystick, b.JoystickButton)
+	}
+	return false
+}
+
+// justReleased reports whether the binding was released since the last frame,
+// even if it was pressed again. Joystick axes are never reported as just
+// released, as their positions are not tracked within a frame.
+func (b Binding) justReleased(in *window.Input) bool {
+	switch b.Kind {
+	case KindKey:
+		return in.JustReleased(b.Key)
+	case KindMouse:
+		return in.ButtonJustReleased(b.Button)
+	case KindJoystickButton:
+		return in.JoystickJustReleased(b.Joystick, b.JoystickButton)
+	}
+	return false
+}
+
+// An AxisBinding binds an axis to a pair of bindings, which move the axis in
+// the negative and positive direction respectively, or to both directions of a
+// joystick axis.
+//
+// The text representation of an axis binding is human-readable; e.g.
+//
+//	A/D
+//	Left/Right
+//	Joystick0 X
+type AxisBinding struct {
+	// Binding which moves the axis in the negative direction.
+	Neg Binding
+	// Binding which moves the axis in the positive direction; or both
+	// directions of a joystick axis.
+	Pos Binding
+}
+
+// Pair returns an axis binding of the provided negative and positive bindings.
+func Pair(neg, pos Binding) AxisBinding {
+	return AxisBinding{Neg: neg, Pos: pos}
+}
+
+// Stick returns an axis binding of both directions of the given axis of the
+// specified joystick.
+func Stick(id int, axis joystick.Axis) AxisBinding {
+	return AxisBinding{Pos: JoystickAxis(id, axis, 0)}
+}
+
+// isStick reports whether the axis binding is bound to both directions of a
+// joystick axis.
+func (b AxisBinding) isStick() bool {
+	return b.Pos.Kind == KindJoystickAxis && b.Pos.Dir == 0
+}
+
+// String returns the human-readable representation of the axis binding.
+func (b AxisBinding) String() string {
+	if b.isStick() {
+		return b.Pos.String()
+	}
+	return b.Neg.String() + "/" + b.Pos.String()
+}
+
+// ParseAxisBinding parses the provided human-readable representation of an
+// axis binding.
+func ParseAxisBinding(s string) (AxisBinding, error) {
+	pos := strings.IndexByte(s, '/')
+	if pos == -1 {
+		b, err := parseBinding(s)
+		if err != nil {
+			return AxisBinding{}, fmt.Errorf("action.ParseAxisBinding: %v", err)
+		}
+		if b.Kind != KindJoystickAxis || b.Dir != 0 {
+			return AxisBinding{}, fmt.Errorf("action.ParseAxisBinding: invalid axis binding %q; expected pair of bindings or joystick axis", s)
+		}
+		return AxisBinding{Pos: b}, nil
+	}
+	neg, err := ParseBinding(s[:pos])
+	if err != nil {
+		return AxisBinding{}, fmt.Errorf("action.ParseAxisBinding: %v", err)
+	}
+	p, err := ParseBinding(s[pos+1:])
+	if err != nil {
+		return AxisBinding{}, fmt.Errorf("action.ParseAxisBinding: %v", err)
+	}
+	return Pair(neg, p), nil
+}
+
+// MarshalText encodes the axis binding in its human-readable representation.
+func (b AxisBinding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes the axis binding from its human-readable
+// representation.
+func (b *AxisBinding) UnmarshalText(text []byte) error {
+	v, err := ParseAxisBinding(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// value returns the value of the axis binding in the range [-1, 1], based on
+// the provided input state. Joystick positions within the dead zone are
+// reported as 0.
+func (b AxisBinding) value(in *window.Input, deadZone float64) float64 {
+	if b.isStick() {
+		v := in.JoystickPos(b.Pos.Joystick, b.Pos.Axis) / 100
+		if v > -deadZone && v < deadZone {
+			return 0
+		}
+		return v
+	}
+	v := 0.0
+	if b.Neg.down(in) {
+		v--
+	}
+	if b.Pos.down(in) {
+		v++
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/we"
+)
+
+func TestBindingText(t *testing.T) {
+	golden := []struct {
+		b    Binding
+		text string
+	}{
+		{b: Key(we.KeySpace), text: "Space"},
+		{b: Key(we.KeyKp0), text: "Keypad0"},
+		{b: Key(we.Key(1000)), text: "Key(1000)"},
+		{b: Mouse(we.ButtonLeft), text: "Mouse Left"},
+		{b: Mouse(we.Button5), text: "Mouse Button5"},
+		{b: Mouse(we.Button(9)), text: "Mouse Button9"},
+		{b: JoystickButton(0, 3), text: "Joystick0 Button3"},
+		{b: JoystickButton(7, 31), text: "Joystick7 Button31"},
+		{b: JoystickAxis(0, joystick.X, 1), text: "Joystick0 X+"},
+		{b: JoystickAxis(1, joystick.PovY, -1), text: "Joystick1 PovY-"},
+	}
+	for _, g := range golden {
+		text, err := g.b.MarshalText()
+		if err != nil {
+			t.Errorf("%+v: unexpected error; %v", g.b, err)
+			continue
+		}
+		if string(text) != g.text {
+			t.Errorf("%+v: text mismatch; expected %q, got %q", g.b, g.text, text)
+			continue
+		}
+		var b Binding
+		if err := b.UnmarshalText(text); err != nil {
+			t.Errorf("%q: unexpected error; %v", text, err)
+			continue
+		}
+		if b != g.b {
+			t.Errorf("%q: binding mismatch; expected %+v, got %+v", text, g.b, b)
+		}
+	}
+}
+
+func TestParseBindingInvalid(t *testing.T) {
+	golden := []string{
+		"",
+		"NoSuchKey",
+		"Key(-1)",
+		"Mouse Side",
+		"Mouse ButtonX",
+		"Mouse Button-1",
+		"JoystickX Button0",
+		"Joystick0 ButtonX",
+		"Joystick0 W+",
+		// Both directions of a joystick axis are only valid in axis bindings.
+		"Joystick0 X",
+		"Space Space Space",
+	}
+	for _, s := range golden {
+		if b, err := ParseBinding(s); err == nil {
+			t.Errorf("%q: expected error, got %+v", s, b)
+		}
+	}
+}
+
+func TestAxisBindingText(t *testing.T) {
+	golden := []struct {
+		b    AxisBinding
+		text string
+	}{
+		{b: Pair(Key(we.KeyA), Key(we.KeyD)), text: "A/D"},
+		{b: Pair(Key(we.KeyLeft), Key(we.KeyRight)), text: "Left/Right"},
+		{b: Pair(JoystickButton(0, 4), JoystickButton(0, 5)), text: "Joystick0 Button4/Joystick0 Button5"},
+		{b: Pair(JoystickAxis(0, joystick.Z, -1), JoystickAxis(0, joystick.R, 1)), text: "Joystick0 Z-/Joystick0 R+"},
+		{b: Stick(0, joystick.X), text: "Joystick0 X"},
+		{b: Stick(2, joystick.V), text: "Joystick2 V"},
+	}
+	for _, g := range golden {
+		text, err := g.b.MarshalText()
+		if err != nil {
+			t.Errorf("%+v: unexpected error; %v", g.b, err)
+			continue
+		}
+		if string(text) != g.text {
+			t.Errorf("%+v: text mismatch; expected %q, got %q", g.b, g.text, text)
+			continue
+		}
+		var b AxisBinding
+		if err := b.UnmarshalText(text); err != nil {
+			t.Errorf("%q: unexpected error; %v", text, err)
+			continue
+		}
+		if b != g.b {
+			t.Errorf("%q: axis binding mismatch; expected %+v, got %+v", text, g.b, b)
+		}
+	}
+}
+
+func TestParseAxisBindingInvalid(t *testing.T) {
+	golden := []string{
+		"",
+		"A",
+		"A/",
+		"/D",
+		"A/NoSuchKey",
+		"Joystick0 X+",
+		"Joystick0 X/Joystick0 Y",
+	}
+	for _, s := range golden {
+		if b, err := ParseAxisBinding(s); err == nil {
+			t.Errorf("%q: expected error, got %+v", s, b)
+		}
+	}
+}
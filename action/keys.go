@@ -0,0 +1,148 @@
+package action
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mewspring/we"
+)
+
+// keyNames maps from keys to their human-readable names.
+var keyNames = map[we.Key]string{
+	we.KeyA:            "A",
+	we.KeyB:            "B",
+	we.KeyC:            "C",
+	we.KeyD:            "D",
+	we.KeyE:            "E",
+	we.KeyF:            "F",
+	we.KeyG:            "G",
+	we.KeyH:            "H",
+	we.KeyI:            "I",
+	we.KeyJ:            "J",
+	we.KeyK:            "K",
+	we.KeyL:            "L",
+	we.KeyM:            "M",
+	we.KeyN:            "N",
+	we.KeyO:            "O",
+	we.KeyP:            "P",
+	we.KeyQ:            "Q",
+	we.KeyR:            "R",
+	we.KeyS:            "S",
+	we.KeyT:            "T",
+	we.KeyU:            "U",
+	we.KeyV:            "V",
+	we.KeyW:            "W",
+	we.KeyX:            "X",
+	we.KeyY:            "Y",
+	we.KeyZ:            "Z",
+	we.Key0:            "0",
+	we.Key1:            "1",
+	we.Key2:            "2",
+	we.Key3:            "3",
+	we.Key4:            "4",
+	we.Key5:            "5",
+	we.Key6:            "6",
+	we.Key7:            "7",
+	we.Key8:            "8",
+	we.Key9:            "9",
+	we.KeyEscape:       "Escape",
+	we.KeyLeftControl:  "LeftControl",
+	we.KeyLeftShift:    "LeftShift",
+	we.KeyLeftAlt:      "LeftAlt",
+	we.KeyLeftSuper:    "LeftSuper",
+	we.KeyRightControl: "RightControl",
+	we.KeyRightShift:   "RightShift",
+	we.KeyRightAlt:     "RightAlt",
+	we.KeyRightSuper:   "RightSuper",
+	we.KeyMenu:         "Menu",
+	we.KeyLeftBracket:  "LeftBracket",
+	we.KeyRightBracket: "RightBracket",
+	we.KeySemicolon:    "Semicolon",
+	we.KeyComma:        "Comma",
+	we.KeyPeriod:       "Period",
+	we.KeyApostrophe:   "Apostrophe",
+	we.KeySlash:        "Slash",
+	we.KeyBackslash:    "Backslash",
+	we.KeyGraveAccent:  "GraveAccent",
+	we.KeyEqual:        "Equal",
+	we.KeyMinus:        "Minus",
+	we.KeySpace:        "Space",
+	we.KeyEnter:        "Enter",
+	we.KeyBackspace:    "Backspace",
+	we.KeyTab:          "Tab",
+	we.KeyPageUp:       "PageUp",
+	we.KeyPageDown:     "PageDown",
+	we.KeyEnd:          "End",
+	we.KeyHome:         "Home",
+	we.KeyInsert:       "Insert",
+	we.KeyDelete:       "Delete",
+	we.KeyKpAdd:        "KeypadAdd",
+	we.KeyKpSubtract:   "KeypadSubtract",
+	we.KeyKpMultiply:   "KeypadMultiply",
+	we.KeyKpDivide:     "KeypadDivide",
+	we.KeyLeft:         "Left",
+	we.KeyRight:        "Right",
+	we.KeyUp:           "Up",
+	we.KeyDown:         "Down",
+	we.KeyKp0:          "Keypad0",
+	we.KeyKp1:          "Keypad1",
+	we.KeyKp2:          "Keypad2",
+	we.KeyKp3:          "Keypad3",
+	we.KeyKp4:          "Keypad4",
+	we.KeyKp5:          "Keypad5",
+	we.KeyKp6:          "Keypad6",
+	we.KeyKp7:          "Keypad7",
+	we.KeyKp8:          "Keypad8",
+	we.KeyKp9:          "Keypad9",
+	we.KeyF1:           "F1",
+	we.KeyF2:           "F2",
+	we.KeyF3:           "F3",
+	we.KeyF4:           "F4",
+	we.KeyF5:           "F5",
+	we.KeyF6:           "F6",
+	we.KeyF7:           "F7",
+	we.KeyF8:           "F8",
+	we.KeyF9:           "F9",
+	we.KeyF10:          "F10",
+	we.KeyF11:          "F11",
+	we.KeyF12:          "F12",
+	we.KeyF13:          "F13",
+	we.KeyF14:          "F14",
+	we.KeyF15:          "F15",
+	we.KeyPause:        "Pause",
+}
+
+// keys maps from human-readable key names to keys.
+var keys = make(map[string]we.Key)
+
+func init() {
+	for key, name := range keyNames {
+		keys[name] = key
+	}
+}
+
+// KeyName returns the human-readable name of the given key; e.g. "Space" or
+// "LeftShift". Keys without a name are formatted as "Key(N)", where N is the
+// numeric value of the key.
+func KeyName(key we.Key) string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key(%d)", int(key))
+}
+
+// ParseKey returns the key of the given human-readable key name, as returned by
+// KeyName. The boolean return value indicates success.
+func ParseKey(name string) (we.Key, bool) {
+	if key, ok := keys[name]; ok {
+		return key, true
+	}
+	if strings.HasPrefix(name, "Key(") && strings.HasSuffix(name, ")") {
+		n, err := strconv.Atoi(name[len("Key(") : len(name)-len(")")])
+		if err == nil && n >= 0 {
+			return we.Key(n), true
+		}
+	}
+	return 0, false
+}
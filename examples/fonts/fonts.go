@@ -8,7 +8,6 @@ import (
 	"log"
 	"path"
 	"runtime"
-	"time"
 
 	"github.com/mewkiz/pkg/goutil"
 	"github.com/mewspring/sfml/font"
@@ -51,6 +50,9 @@ func fonts() (err error) {
 	}
 	defer win.Close()
 
+	// Cap the FPS to 60.
+	win.SetFramerateLimit(60)
+
 	// Load background texture.
 	bg, err := texture.Load(path.Join(dataDir, "bg2.png"))
 	if err != nil {
@@ -89,19 +91,8 @@ func fonts() (err error) {
 	}
 	defer fps.Free()
 
-	// start and frames will be used to calculate the average FPS of the
-	// application.
-	start := time.Now()
-	frames := 0.0
-
-	// 60 FPS
-	ticker := time.NewTicker(time.Second / 60)
-
 	// Drawing and event loop.
 	for {
-		// Cap the FPS.
-		<-ticker.C
-
 		// Fill the window with white color.
 		win.Fill(color.White)
 
@@ -120,7 +111,7 @@ func fonts() (err error) {
 		}
 
 		// Update the text of the FPS text entry.
-		fps.SetText(getFPS(start, frames))
+		fps.SetText(getFPS(win.FrameStats()))
 
 		// Draw the entire FPS text entry onto the screen starting at the
 		// destination point (8, 4).
@@ -132,7 +123,6 @@ func fonts() (err error) {
 
 		// Display what has been rendered so far to the window.
 		win.Display()
-		frames++
 
 		// Poll events until the event queue is empty.
 		for e := win.PollEvent(); e != nil; e = win.PollEvent() {
@@ -146,10 +136,8 @@ func fonts() (err error) {
 	}
 }
 
-// getFPS returns the average FPS as a string, based on the provided start time
-// and frame count.
-func getFPS(start time.Time, frames float64) (text string) {
-	// Average FPS.
-	fps := frames / time.Since(start).Seconds()
-	return fmt.Sprintf("FPS: %.2f", fps)
+// getFPS returns the average FPS as a string, based on the provided frame
+// timing statistics.
+func getFPS(stats window.FrameStats) (text string) {
+	return fmt.Sprintf("FPS: %.2f", stats.FPS)
 }
@@ -9,6 +9,7 @@ import (
 	"path"
 
 	"github.com/mewkiz/pkg/goutil"
+	"github.com/mewspring/sfml/mainthread"
 	"github.com/mewspring/sfml/texture"
 	"github.com/mewspring/sfml/window"
 	"github.com/mewspring/we"
@@ -27,7 +28,13 @@ func init() {
 }
 
 func main() {
-	err := many()
+	// Windows are opened from separate goroutines. Start the program using
+	// mainthread.Run, so that window creation and event handling take place on
+	// the main thread.
+	var err error
+	mainthread.Run(func() {
+		err = many()
+	})
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -61,6 +68,9 @@ func tiny(title string) (err error) {
 		return err
 	}
 	defer win.Close()
+
+	// Cap the FPS to 60, to avoid busy-looping.
+	win.SetFramerateLimit(60)
 	win.SetTitle(title)
 
 	// Load background texture.
@@ -44,6 +44,9 @@ func tiny() (err error) {
 	}
 	defer win.Close()
 
+	// Cap the FPS to 60, to avoid busy-looping.
+	win.SetFramerateLimit(60)
+
 	// Load background texture.
 	bg, err := texture.Load(path.Join(dataDir, "bg.png"))
 	if err != nil {
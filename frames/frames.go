@@ -0,0 +1,165 @@
+// Package frames records the rendered frames of windows and drawable textures,
+// and encodes them as a numbered PNG sequence, an animated GIF or an
+// uncompressed YUV4MPEG2 stream.
+//
+// A window is recorded by capturing every frame before it is displayed.
+//
+//	enc := frames.NewGIF(f, time.Second/30)
+//	rec := frames.NewRecorder(win.Capture, enc, 2, true)
+//	win.OnDisplay(func() { rec.Frame() })
+//	...
+//	win.OnDisplay(nil)
+//	if err := rec.Close(); err != nil {
+//		log.Fatal(err)
+//	}
+package frames
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+)
+
+// An Encoder encodes a sequence of frames.
+type Encoder interface {
+	// Encode encodes the provided frame.
+	Encode(img image.Image) error
+	// Close finishes the encoding of the frames.
+	Close() error
+}
+
+// A Recorder captures every Nth frame of a render target and encodes it.
+type Recorder struct {
+	// Captures the current contents of the render target; e.g. the Capture
+	// method of a window or the Image method of a drawable texture.
+	capture func() (image.Image, error)
+	// Encoder of the captured frames.
+	enc Encoder
+	// Capture every Nth frame.
+	every int
+	// Number of frames seen by the recorder.
+	n int
+	// Captured frames to be encoded asynchronously; or nil if frames are
+	// encoded synchronously.
+	queue chan image.Image
+	// Closed when all queued frames have been encoded.
+	done chan struct{}
+	// Protects closed, and is held while a frame is recorded.
+	closeMu sync.Mutex
+	// Specifies whether the recorder is closed.
+	closed bool
+	// Protects err.
+	mu sync.Mutex
+	// First error encountered while recording; or nil.
+	err error
+}
+
+// NewRecorder returns a new recorder which captures every Nth frame using the
+// provided capture function, and encodes the captured frames using enc. If
+// async is true, frames are captured synchronously but encoded in a separate
+// goroutine.
+func NewRecorder(capture func() (image.Image, error), enc Encoder, every int, async bool) *Recorder {
+	if every < 1 {
+		every = 1
+	}
+	rec := &Recorder{
+		capture: capture,
+		enc:     enc,
+		every:   every,
+	}
+	if async {
+		rec.queue = make(chan image.Image, 16)
+		rec.done = make(chan struct{})
+		go rec.encodeQueue()
+	}
+	return rec
+}
+
+// Frame notifies the recorder of a new frame, which is captured and encoded if
+// it is the Nth frame since the last captured one. It should be called once per
+// frame, before the frame is displayed.
+//
+// Errors are also retained by the recorder and returned by Close, which allows
+// Frame to be used as a display hook whose return value is ignored. Frame
+// returns an error if the recorder is closed.
+func (rec *Recorder) Frame() error {
+	rec.closeMu.Lock()
+	defer rec.closeMu.Unlock()
+	if rec.closed {
+		return errors.New("Recorder.Frame: recorder closed")
+	}
+	n := rec.n
+	rec.n++
+	if n%rec.every != 0 {
+		return nil
+	}
+	if err := rec.Err(); err != nil {
+		return err
+	}
+	img, err := rec.capture()
+	if err != nil {
+		return rec.fail(fmt.Errorf("Recorder.Frame: unable to capture frame %d; %v", n, err))
+	}
+	if rec.queue != nil {
+		rec.queue <- img
+		return nil
+	}
+	if err := rec.enc.Encode(img); err != nil {
+		return rec.fail(fmt.Errorf("Recorder.Frame: unable to encode frame %d; %v", n, err))
+	}
+	return nil
+}
+
+// Err returns the first error encountered while recording, if any.
+func (rec *Recorder) Err() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.err
+}
+
+// Close waits for all captured frames to be encoded and closes the encoder. It
+// returns the first error encountered while recording, if any. Subsequent calls
+// to Close return the same error without closing the encoder again.
+func (rec *Recorder) Close() error {
+	rec.closeMu.Lock()
+	closed := rec.closed
+	rec.closed = true
+	rec.closeMu.Unlock()
+	if closed {
+		return rec.Err()
+	}
+	if rec.queue != nil {
+		close(rec.queue)
+		<-rec.done
+	}
+	if err := rec.enc.Close(); err != nil {
+		rec.fail(fmt.Errorf("Recorder.Close: %v", err))
+	}
+	return rec.Err()
+}
+
+// encodeQueue encodes the queued frames until the queue is closed.
+func (rec *Recorder) encodeQueue() {
+	defer close(rec.done)
+	for img := range rec.queue {
+		if rec.Err() != nil {
+			// Drain the queue after the first error.
+			continue
+		}
+		if err := rec.enc.Encode(img); err != nil {
+			rec.fail(fmt.Errorf("Recorder.Frame: unable to encode frame; %v", err))
+		}
+	}
+}
+
+// fail records the provided error, unless an error has already been recorded.
+// It returns the provided error.
+func (rec *Recorder) fail(err error) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.err == nil {
+		rec.err = err
+	}
+	return err
+}
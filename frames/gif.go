@@ -0,0 +1,55 @@
+package frames
+
+import (
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// gifEncoder encodes frames as an animated GIF.
+type gifEncoder struct {
+	// Output of the animated GIF.
+	w io.Writer
+	// Delay between frames, in 100ths of a second.
+	delay int
+	// Animated GIF of the encoded frames.
+	anim gif.GIF
+}
+
+// NewGIF returns a new encoder which writes the frames as an animated GIF to w,
+// with the provided delay between frames. The frames are dithered to the Plan 9
+// color palette.
+//
+// Note: The animated GIF is written to w by Close, and the frames are kept in
+// memory until then.
+func NewGIF(w io.Writer, delay time.Duration) Encoder {
+	d := int(delay / (10 * time.Millisecond))
+	if d < 1 {
+		d = 1
+	}
+	return &gifEncoder{
+		w:     w,
+		delay: d,
+	}
+}
+
+// Encode encodes the provided frame.
+func (enc *gifEncoder) Encode(img image.Image) error {
+	bounds := img.Bounds()
+	dst := image.NewPaletted(bounds, palette.Plan9)
+	draw.FloydSteinberg.Draw(dst, bounds, img, bounds.Min)
+	enc.anim.Image = append(enc.anim.Image, dst)
+	enc.anim.Delay = append(enc.anim.Delay, enc.delay)
+	return nil
+}
+
+// Close finishes the encoding of the frames.
+func (enc *gifEncoder) Close() error {
+	if len(enc.anim.Image) == 0 {
+		return nil
+	}
+	return gif.EncodeAll(enc.w, &enc.anim)
+}
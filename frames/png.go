@@ -0,0 +1,45 @@
+package frames
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+)
+
+// pngSequence encodes frames as a numbered sequence of PNG files.
+type pngSequence struct {
+	// File name pattern of the frames.
+	pattern string
+	// Number of the next frame.
+	n int
+}
+
+// NewPNGSequence returns a new encoder which writes each frame to a separate
+// PNG file. The file names are formatted by the provided pattern and the frame
+// number, starting at 0; e.g. "frame_%05d.png".
+func NewPNGSequence(pattern string) Encoder {
+	return &pngSequence{
+		pattern: pattern,
+	}
+}
+
+// Encode encodes the provided frame.
+func (enc *pngSequence) Encode(img image.Image) error {
+	path := fmt.Sprintf(enc.pattern, enc.n)
+	enc.n++
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to encode %q; %v", path, err)
+	}
+	return f.Close()
+}
+
+// Close finishes the encoding of the frames.
+func (enc *pngSequence) Close() error {
+	return nil
+}
@@ -0,0 +1,73 @@
+package frames
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+// y4mEncoder encodes frames as an uncompressed YUV4MPEG2 stream.
+type y4mEncoder struct {
+	// Buffered output of the stream.
+	w *bufio.Writer
+	// Frame rate of the stream.
+	fps int
+	// Dimensions of the frames; or the zero rectangle before the first frame.
+	bounds image.Rectangle
+	// Y, Cb and Cr planes of the current frame.
+	planes [3][]byte
+}
+
+// NewY4M returns a new encoder which writes the frames to w as an uncompressed
+// YUV4MPEG2 stream with the provided frame rate, which external tools such as
+// ffmpeg may transcode. The stream uses full range 4:4:4 chroma sampling and the
+// dimensions of the first frame.
+func NewY4M(w io.Writer, fps int) Encoder {
+	return &y4mEncoder{
+		w:   bufio.NewWriter(w),
+		fps: fps,
+	}
+}
+
+// Encode encodes the provided frame.
+func (enc *y4mEncoder) Encode(img image.Image) error {
+	bounds := img.Bounds()
+	if enc.bounds.Empty() {
+		enc.bounds = bounds
+		header := fmt.Sprintf("YUV4MPEG2 W%d H%d F%d:1 Ip A1:1 C444 XCOLORRANGE=FULL\n", bounds.Dx(), bounds.Dy(), enc.fps)
+		if _, err := enc.w.WriteString(header); err != nil {
+			return err
+		}
+		n := bounds.Dx() * bounds.Dy()
+		for i := range enc.planes {
+			enc.planes[i] = make([]byte, n)
+		}
+	}
+	if bounds.Size() != enc.bounds.Size() {
+		return fmt.Errorf("invalid frame dimensions %v; expected %v", bounds.Size(), enc.bounds.Size())
+	}
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			enc.planes[0][i], enc.planes[1][i], enc.planes[2][i] = color.RGBToYCbCr(c.R, c.G, c.B)
+			i++
+		}
+	}
+	if _, err := enc.w.WriteString("FRAME\n"); err != nil {
+		return err
+	}
+	for _, plane := range enc.planes {
+		if _, err := enc.w.Write(plane); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finishes the encoding of the frames.
+func (enc *y4mEncoder) Close() error {
+	return enc.w.Flush()
+}
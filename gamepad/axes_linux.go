@@ -0,0 +1,20 @@
+//go:build linux
+
+package gamepad
+
+import (
+	"github.com/mewspring/sfml/joystick"
+)
+
+// sdlAxes lists the joystick axes in the order of their SDL axis indices,
+// excluding the point-of-view hat. SDL enumerates the evdev axes ABS_X, ABS_Y,
+// ABS_Z, ABS_RX, ABS_RY and ABS_RZ in order, which SFML maps to the X, Y, Z,
+// U, V and R axes respectively.
+var sdlAxes = []joystick.Axis{
+	joystick.X,
+	joystick.Y,
+	joystick.Z,
+	joystick.U,
+	joystick.V,
+	joystick.R,
+}
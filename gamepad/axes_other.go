@@ -0,0 +1,18 @@
+//go:build !linux
+
+package gamepad
+
+import (
+	"github.com/mewspring/sfml/joystick"
+)
+
+// sdlAxes lists the joystick axes in the order of their SDL axis indices,
+// excluding the point-of-view hat.
+var sdlAxes = []joystick.Axis{
+	joystick.X,
+	joystick.Y,
+	joystick.Z,
+	joystick.R,
+	joystick.U,
+	joystick.V,
+}
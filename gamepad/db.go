@@ -0,0 +1,290 @@
+package gamepad
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mewspring/sfml/joystick"
+)
+
+// builtin holds the built-in game controller mappings.
+//
+//go:embed gamecontrollerdb.txt
+var builtin string
+
+// A Mapping maps the buttons and axes of a joystick model to the standard
+// gamepad layout.
+type Mapping struct {
+	// SDL GUID of the joystick model.
+	GUID string
+	// Name of the joystick model.
+	Name string
+	// USB vendor ID of the joystick model.
+	VendorID int
+	// USB product ID of the joystick model.
+	ProductID int
+	// Joystick inputs of the gamepad buttons.
+	buttons map[Button]input
+	// Joystick inputs of the gamepad axes.
+	axes map[Axis]input
+}
+
+// inputKind specifies the kind of a joystick input.
+type inputKind int
+
+// Joystick input kinds.
+const (
+	// kindButton is a joystick button.
+	kindButton inputKind = iota + 1
+	// kindAxis is a joystick axis.
+	kindAxis
+	// kindHat is a direction of the point-of-view hat.
+	kindHat
+)
+
+// An input specifies a joystick button, axis or hat direction.
+type input struct {
+	// Kind of the joystick input.
+	kind inputKind
+	// Button index or SDL axis index of the joystick input.
+	index int
+	// Hat directions of the joystick input.
+	mask int
+	// Half of the axis used; positive (1), negative (-1) or both (0).
+	half int
+	// Specifies whether the axis is inverted.
+	invert bool
+}
+
+// product identifies a joystick model by its USB vendor and product IDs.
+type product struct {
+	vendor, product int
+}
+
+// A DB is a database of game controller mappings. It is safe for concurrent
+// use.
+type DB struct {
+	// Protects mappings.
+	mu sync.RWMutex
+	// Game controller mappings of the database.
+	mappings map[product]*Mapping
+}
+
+// NewDB returns a new database of game controller mappings, initialized with
+// the built-in mappings.
+func NewDB() *DB {
+	db := &DB{
+		mappings: make(map[product]*Mapping),
+	}
+	if err := db.Read(strings.NewReader(builtin)); err != nil {
+		panic(fmt.Errorf("gamepad.NewDB: invalid built-in mappings; %v", err))
+	}
+	return db
+}
+
+// Load loads the game controller mappings of the provided file, in the SDL
+// GameControllerDB format. The loaded mappings take precedence over existing
+// mappings of the same joystick models.
+func (db *DB) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("DB.Load: unable to open %q; %v", path, err)
+	}
+	defer f.Close()
+	return db.Read(f)
+}
+
+// Read reads game controller mappings in the SDL GameControllerDB format from
+// r. The read mappings take precedence over existing mappings of the same
+// joystick models.
+//
+// Mappings of other platforms and mappings whose GUIDs do not contain USB
+// vendor and product IDs are skipped.
+func (db *DB) Read(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := strings.TrimSpace(s.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		mapping, err := parseMapping(line)
+		if err != nil {
+			return fmt.Errorf("DB.Read: line %d; %v", lineNum, err)
+		}
+		if mapping == nil {
+			continue
+		}
+		key := product{vendor: mapping.VendorID, product: mapping.ProductID}
+		db.mu.Lock()
+		db.mappings[key] = mapping
+		db.mu.Unlock()
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("DB.Read: %v", err)
+	}
+	return nil
+}
+
+// Lookup returns the mapping of the joystick model with the given USB vendor
+// and product IDs. The boolean return value indicates success.
+func (db *DB) Lookup(vendorID, productID int) (*Mapping, bool) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	mapping, ok := db.mappings[product{vendor: vendorID, product: productID}]
+	return mapping, ok
+}
+
+// Gamepad returns the specified joystick as a gamepad. The boolean return value
+// indicates success; it is false if the joystick is not connected or if no
+// mapping is known for its joystick model.
+func (db *DB) Gamepad(id int) (*Gamepad, bool) {
+	dev, ok := joystick.Lookup(id)
+	if !ok {
+		return nil, false
+	}
+	mapping, ok := db.Lookup(dev.VendorID, dev.ProductID)
+	if !ok {
+		return nil, false
+	}
+	pad := &Gamepad{
+		ID:      id,
+		Mapping: mapping,
+	}
+	// SDL axis indices enumerate the axes present on the joystick, excluding
+	// hats, in the platform specific order of sdlAxes.
+	present := make(map[joystick.Axis]bool)
+	for _, axis := range dev.Axes {
+		present[axis] = true
+	}
+	for _, axis := range sdlAxes {
+		if present[axis] {
+			pad.axes = append(pad.axes, axis)
+		}
+	}
+	return pad, true
+}
+
+// platforms maps from Go operating systems to SDL platform names.
+var platforms = map[string]string{
+	"linux":   "Linux",
+	"windows": "Windows",
+	"darwin":  "Mac OS X",
+	"android": "Android",
+	"ios":     "iOS",
+}
+
+// parseMapping parses the provided game controller mapping line. A nil mapping
+// is returned if the mapping is not applicable to the current platform or if
+// its GUID does not contain USB vendor and product IDs.
+func parseMapping(line string) (*Mapping, error) {
+	fields := strings.Split(strings.TrimSuffix(line, ","), ",")
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid mapping %q; expected GUID and name", line)
+	}
+	mapping := &Mapping{
+		GUID:    fields[0],
+		Name:    fields[1],
+		buttons: make(map[Button]input),
+		axes:    make(map[Axis]input),
+	}
+	guid, err := hex.DecodeString(mapping.GUID)
+	if err != nil || len(guid) != 16 {
+		return nil, fmt.Errorf("invalid GUID %q", mapping.GUID)
+	}
+	// Bytes 4-5 and 8-9 of the GUID hold the little-endian vendor and product
+	// IDs, provided that bytes 6-7 and 10-11 are zero.
+	if guid[6]|guid[7]|guid[10]|guid[11] != 0 {
+		return nil, nil
+	}
+	mapping.VendorID = int(guid[4]) | int(guid[5])<<8
+	mapping.ProductID = int(guid[8]) | int(guid[9])<<8
+	for _, field := range fields[2:] {
+		pos := strings.IndexByte(field, ':')
+		if pos == -1 {
+			return nil, fmt.Errorf("invalid mapping element %q; missing ':'", field)
+		}
+		name, val := field[:pos], field[pos+1:]
+		if name == "platform" {
+			if val != platforms[runtime.GOOS] {
+				return nil, nil
+			}
+			continue
+		}
+		button, isButton := buttonNames[name]
+		axis, isAxis := axisNames[name]
+		if !isButton && !isAxis {
+			// Skip unsupported elements, such as half output axes, paddles and
+			// hints.
+			continue
+		}
+		// Only the first hat is supported by SFML.
+		if strings.HasPrefix(val, "h") && !strings.HasPrefix(val, "h0.") {
+			continue
+		}
+		in, err := parseInput(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping element %q; %v", field, err)
+		}
+		if isButton {
+			mapping.buttons[button] = in
+		} else {
+			mapping.axes[axis] = in
+		}
+	}
+	return mapping, nil
+}
+
+// parseInput parses the provided joystick input of a mapping element; e.g.
+// "b0", "a2", "+a3", "a1~" or "h0.4".
+func parseInput(s string) (input, error) {
+	var in input
+	switch {
+	case strings.HasPrefix(s, "+"):
+		in.half = 1
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		in.half = -1
+		s = s[1:]
+	}
+	if strings.HasSuffix(s, "~") {
+		in.invert = true
+		s = s[:len(s)-1]
+	}
+	if len(s) < 2 {
+		return input{}, fmt.Errorf("invalid joystick input %q", s)
+	}
+	switch s[0] {
+	case 'b':
+		in.kind = kindButton
+	case 'a':
+		in.kind = kindAxis
+	case 'h':
+		in.kind = kindHat
+		pos := strings.IndexByte(s, '.')
+		if pos == -1 {
+			return input{}, fmt.Errorf("invalid hat %q; missing '.'", s)
+		}
+		mask, err := strconv.Atoi(s[pos+1:])
+		if err != nil {
+			return input{}, fmt.Errorf("invalid hat mask %q; %v", s, err)
+		}
+		in.mask = mask
+		return in, nil
+	default:
+		return input{}, fmt.Errorf("invalid joystick input %q", s)
+	}
+	index, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return input{}, fmt.Errorf("invalid index of joystick input %q; %v", s, err)
+	}
+	in.index = index
+	return in, nil
+}
@@ -0,0 +1,125 @@
+package gamepad
+
+import (
+	"testing"
+)
+
+func TestParseMappingGUID(t *testing.T) {
+	golden := []struct {
+		line      string
+		vendorID  int
+		productID int
+		// Reports whether the mapping is skipped.
+		skip bool
+		// Reports whether parsing fails.
+		fail bool
+	}{
+		// Xbox 360 Controller.
+		{
+			line:      "030000005e0400008e02000010010000,Xbox 360 Controller,a:b0",
+			vendorID:  0x045E,
+			productID: 0x028E,
+		},
+		// Sony DualShock 4.
+		{
+			line:      "030000004c050000c405000011010000,PS4 Controller,a:b1,",
+			vendorID:  0x054C,
+			productID: 0x05C4,
+		},
+		// GUID without USB vendor and product IDs.
+		{
+			line: "05000000504c415953544154494f4e00,PLAYSTATION(R)3 Controller,a:b0",
+			skip: true,
+		},
+		// Mapping of another platform.
+		{
+			line: "030000005e0400008e02000010010000,Xbox 360 Controller,a:b0,platform:Plan 9",
+			skip: true,
+		},
+		// Invalid GUID.
+		{
+			line: "030000005e0400008e020000,Xbox 360 Controller,a:b0",
+			fail: true,
+		},
+		{
+			line: "03000000zz0400008e02000010010000,Xbox 360 Controller,a:b0",
+			fail: true,
+		},
+		// Missing name.
+		{
+			line: "030000005e0400008e02000010010000",
+			fail: true,
+		},
+	}
+	for _, g := range golden {
+		mapping, err := parseMapping(g.line)
+		if g.fail {
+			if err == nil {
+				t.Errorf("%q: expected error, got nil", g.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error; %v", g.line, err)
+			continue
+		}
+		if g.skip {
+			if mapping != nil {
+				t.Errorf("%q: expected skipped mapping, got %+v", g.line, mapping)
+			}
+			continue
+		}
+		if mapping == nil {
+			t.Errorf("%q: unexpected skipped mapping", g.line)
+			continue
+		}
+		if mapping.VendorID != g.vendorID || mapping.ProductID != g.productID {
+			t.Errorf("%q: vendor and product ID mismatch; expected %04X:%04X, got %04X:%04X", g.line, g.vendorID, g.productID, mapping.VendorID, mapping.ProductID)
+		}
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	golden := []struct {
+		s    string
+		want input
+		fail bool
+	}{
+		{s: "b0", want: input{kind: kindButton, index: 0}},
+		{s: "b12", want: input{kind: kindButton, index: 12}},
+		{s: "a2", want: input{kind: kindAxis, index: 2}},
+		// Half axes.
+		{s: "+a3", want: input{kind: kindAxis, index: 3, half: 1}},
+		{s: "-a1", want: input{kind: kindAxis, index: 1, half: -1}},
+		// Inverted axes.
+		{s: "a1~", want: input{kind: kindAxis, index: 1, invert: true}},
+		{s: "-a5~", want: input{kind: kindAxis, index: 5, half: -1, invert: true}},
+		// Hat directions.
+		{s: "h0.1", want: input{kind: kindHat, mask: 1}},
+		{s: "h0.4", want: input{kind: kindHat, mask: 4}},
+		{s: "h0.8", want: input{kind: kindHat, mask: 8}},
+		// Invalid inputs.
+		{s: "", fail: true},
+		{s: "a", fail: true},
+		{s: "x1", fail: true},
+		{s: "bx", fail: true},
+		{s: "h0", fail: true},
+		{s: "h0.x", fail: true},
+	}
+	for _, g := range golden {
+		got, err := parseInput(g.s)
+		if g.fail {
+			if err == nil {
+				t.Errorf("%q: expected error, got nil", g.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error; %v", g.s, err)
+			continue
+		}
+		if got != g.want {
+			t.Errorf("%q: input mismatch; expected %+v, got %+v", g.s, g.want, got)
+		}
+	}
+}
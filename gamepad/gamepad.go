@@ -0,0 +1,223 @@
+// Package gamepad provides a standard gamepad layout on top of joystick input.
+// Raw joystick buttons and axes are translated to the standard layout using
+// mappings in the SDL GameControllerDB text format [1], keyed by the USB vendor
+// and product IDs of the joystick.
+//
+// [1]: https://github.com/gabomdq/SDL_GameControllerDB
+package gamepad
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/mewspring/sfml/joystick"
+)
+
+// Button specifies a button of the standard gamepad layout.
+type Button int
+
+// Gamepad buttons.
+const (
+	// A is the bottom face button.
+	A Button = iota
+	// B is the right face button.
+	B
+	// X is the left face button.
+	X
+	// Y is the top face button.
+	Y
+	// Back is the back (or select) button.
+	Back
+	// Guide is the guide (or home) button.
+	Guide
+	// Start is the start button.
+	Start
+	// LeftStick is the button of the left stick.
+	LeftStick
+	// RightStick is the button of the right stick.
+	RightStick
+	// LeftShoulder is the left shoulder button.
+	LeftShoulder
+	// RightShoulder is the right shoulder button.
+	RightShoulder
+	// DPadUp is the up button of the directional pad.
+	DPadUp
+	// DPadDown is the down button of the directional pad.
+	DPadDown
+	// DPadLeft is the left button of the directional pad.
+	DPadLeft
+	// DPadRight is the right button of the directional pad.
+	DPadRight
+)
+
+// buttonNames maps from gamepad button names, as used in the SDL
+// GameControllerDB format, to gamepad buttons.
+var buttonNames = map[string]Button{
+	"a":             A,
+	"b":             B,
+	"x":             X,
+	"y":             Y,
+	"back":          Back,
+	"guide":         Guide,
+	"start":         Start,
+	"leftstick":     LeftStick,
+	"rightstick":    RightStick,
+	"leftshoulder":  LeftShoulder,
+	"rightshoulder": RightShoulder,
+	"dpup":          DPadUp,
+	"dpdown":        DPadDown,
+	"dpleft":        DPadLeft,
+	"dpright":       DPadRight,
+}
+
+// String returns the name of the gamepad button.
+func (button Button) String() string {
+	for name, b := range buttonNames {
+		if b == button {
+			return name
+		}
+	}
+	return fmt.Sprintf("Button(%d)", int(button))
+}
+
+// Axis specifies an axis of the standard gamepad layout.
+type Axis int
+
+// Gamepad axes.
+const (
+	// LeftX is the horizontal axis of the left stick.
+	LeftX Axis = iota
+	// LeftY is the vertical axis of the left stick.
+	LeftY
+	// RightX is the horizontal axis of the right stick.
+	RightX
+	// RightY is the vertical axis of the right stick.
+	RightY
+	// LeftTrigger is the left trigger.
+	LeftTrigger
+	// RightTrigger is the right trigger.
+	RightTrigger
+)
+
+// axisNames maps from gamepad axis names, as used in the SDL GameControllerDB
+// format, to gamepad axes.
+var axisNames = map[string]Axis{
+	"leftx":        LeftX,
+	"lefty":        LeftY,
+	"rightx":       RightX,
+	"righty":       RightY,
+	"lefttrigger":  LeftTrigger,
+	"righttrigger": RightTrigger,
+}
+
+// String returns the name of the gamepad axis.
+func (axis Axis) String() string {
+	for name, a := range axisNames {
+		if a == axis {
+			return name
+		}
+	}
+	return fmt.Sprintf("Axis(%d)", int(axis))
+}
+
+// A Gamepad is a joystick with a known mapping to the standard gamepad layout.
+type Gamepad struct {
+	// Index of the joystick.
+	ID int
+	// Mapping of the joystick.
+	Mapping *Mapping
+	// Joystick axes of the joystick, excluding the point-of-view hat; indexed
+	// by SDL axis index.
+	axes []joystick.Axis
+}
+
+// Pressed reports whether the given button of the gamepad is pressed.
+func (pad *Gamepad) Pressed(button Button) bool {
+	in, ok := pad.Mapping.buttons[button]
+	if !ok {
+		return false
+	}
+	return pad.value(in) > 0.5
+}
+
+// Pos returns the current position of the given axis of the gamepad. Stick
+// positions are in the range [-1, 1], where negative is left or up; trigger
+// positions are in the range [0, 1], where 0 is released.
+func (pad *Gamepad) Pos(axis Axis) float64 {
+	in, ok := pad.Mapping.axes[axis]
+	if !ok {
+		return 0
+	}
+	v := pad.value(in)
+	if (axis == LeftTrigger || axis == RightTrigger) && in.kind == kindAxis && in.half == 0 {
+		// Full range trigger axes rest at -1.
+		v = (v + 1) / 2
+	}
+	return v
+}
+
+// value returns the current value of the given joystick input, in the range
+// [-1, 1].
+func (pad *Gamepad) value(in input) float64 {
+	switch in.kind {
+	case kindButton:
+		if joystick.Pressed(pad.ID, in.index) {
+			return 1
+		}
+		return 0
+	case kindAxis:
+		if in.index >= len(pad.axes) {
+			return 0
+		}
+		v := joystick.Pos(pad.ID, pad.axes[in.index]) / 100
+		if in.invert {
+			v = -v
+		}
+		switch {
+		case in.half > 0 && v < 0, in.half < 0 && v > 0:
+			return 0
+		case in.half < 0:
+			return -v
+		}
+		return v
+	case kindHat:
+		if hat(pad.ID)&in.mask != 0 {
+			return 1
+		}
+		return 0
+	}
+	return 0
+}
+
+// Hat directions, as used by the SDL GameControllerDB format.
+const (
+	hatUp    = 1
+	hatRight = 2
+	hatDown  = 4
+	hatLeft  = 8
+)
+
+// hat returns the directions in which the point-of-view hat of the specified
+// joystick is pressed.
+func hat(id int) int {
+	// SFML reports upwards hat movements as positive PovY on Windows and as
+	// negative PovY on other platforms.
+	x, y := joystick.Pos(id, joystick.PovX), joystick.Pos(id, joystick.PovY)
+	if runtime.GOOS == "windows" {
+		y = -y
+	}
+	dirs := 0
+	switch {
+	case x > 50:
+		dirs |= hatRight
+	case x < -50:
+		dirs |= hatLeft
+	}
+	switch {
+	case y > 50:
+		dirs |= hatDown
+	case y < -50:
+		dirs |= hatUp
+	}
+	return dirs
+}
@@ -0,0 +1,221 @@
+// Package drawopt implements the draw options of the texture and window
+// packages, which apply them to SFML sprites, texts and render states.
+//
+// The draw option types are declared once in this package, and aliased by the
+// texture package. C types are distinct between packages, so sprites, texts and
+// render states are passed as unsafe pointers.
+package drawopt
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"unsafe"
+)
+
+// Options specifies optional transformations, color modulation and blending of
+// a draw operation. The zero value draws the source image unmodified, using
+// alpha blending.
+type Options struct {
+	// Scale factors along the X and Y axes, relative to the origin. A factor of
+	// 0 denotes no scaling along the axis.
+	ScaleX, ScaleY float64
+	// Clockwise rotation in degrees around the origin.
+	Rotation float64
+	// Origin of the scaling and rotation, relative to the top-left corner of
+	// the source rectangle. The origin is drawn at the destination point.
+	Origin image.Point
+	// Flip the source image horizontally and vertically, respectively. Text is
+	// flipped around the origin.
+	FlipX, FlipY bool
+	// Affine transformation applied after all other transformations; or nil
+	// if none.
+	Transform *Transform
+	// Color which modulates the colors of the source image, including its
+	// alpha component; or nil if the colors are not modulated (white). For
+	// instance, a red modulation color keeps only the red channel.
+	Color color.Color
+	// Fraction by which the source image is faded out, in the range [0, 1];
+	// 0 is fully opaque and 1 is fully transparent.
+	Fade float64
+	// Blend mode of the draw operation; e.g. &texture.BlendAdd. A nil blend
+	// mode denotes texture.BlendAlpha.
+	Blend *BlendMode
+}
+
+// A Transform is a 2D affine transformation, specified by the first two rows of
+// a 3x3 matrix in row-major order. The transformation maps the point (x, y) to
+// (a*x + b*y + c, d*x + e*y + f), where [a, b, c, d, e, f] are the elements of
+// the transform.
+type Transform [6]float64
+
+// BlendFactor specifies the factor by which the source or destination
+// components are multiplied when blending.
+type BlendFactor int
+
+// BlendEquation specifies how the multiplied source and destination components
+// are combined when blending.
+type BlendEquation int
+
+// A BlendMode specifies how the colors of drawn pixels are blended with the
+// colors of the destination pixels. The color and alpha components are blended
+// separately.
+type BlendMode struct {
+	// Factors of the source and destination color components.
+	ColorSrc, ColorDst BlendFactor
+	// Equation of the color components.
+	ColorEquation BlendEquation
+	// Factors of the source and destination alpha components.
+	AlphaSrc, AlphaDst BlendFactor
+	// Equation of the alpha components.
+	AlphaEquation BlendEquation
+}
+
+// SetSprite sets the texture rectangle, position, transformations and color of
+// the sprite for drawing the source rectangle sr at the destination point dp, based
+// on the provided draw options.
+func SetSprite(s unsafe.Pointer, dp image.Point, sr image.Rectangle, opts *Options) {
+	sprite := (*C.sfSprite)(s)
+	rect := sfmlIntRect(sr)
+	if opts != nil {
+		// Flip the source image by inverting the texture rectangle.
+		if opts.FlipX {
+			rect.left, rect.width = rect.left+rect.width, -rect.width
+		}
+		if opts.FlipY {
+			rect.top, rect.height = rect.top+rect.height, -rect.height
+		}
+		C.sfSprite_setOrigin(sprite, sfmlFloatPt(opts.Origin))
+		C.sfSprite_setScale(sprite, sfmlScale(opts.ScaleX, opts.ScaleY, false, false))
+		C.sfSprite_setRotation(sprite, C.float(opts.Rotation))
+		C.sfSprite_setColor(sprite, modulate(color.White, opts))
+	}
+	C.sfSprite_setTextureRect(sprite, rect)
+	C.sfSprite_setPosition(sprite, sfmlFloatPt(dp))
+}
+
+// ResetSprite resets the transformations and color of the sprite set by
+// SetSprite.
+func ResetSprite(s unsafe.Pointer) {
+	sprite := (*C.sfSprite)(s)
+	C.sfSprite_setOrigin(sprite, sfmlFloatPt(image.ZP))
+	C.sfSprite_setScale(sprite, sfmlScale(1, 1, false, false))
+	C.sfSprite_setRotation(sprite, 0)
+	C.sfSprite_setColor(sprite, C.sfWhite)
+}
+
+// SetText sets the position, transformations and color of the text for drawing
+// at the destination point dp, based on the provided draw options. The original
+// fill color of the text is returned, to be restored by ResetText.
+func SetText(t unsafe.Pointer, dp image.Point, opts *Options) color.NRGBA {
+	text := (*C.sfText)(t)
+	c := C.sfText_getFillColor(text)
+	orig := color.NRGBA{R: uint8(c.r), G: uint8(c.g), B: uint8(c.b), A: uint8(c.a)}
+	if opts != nil {
+		C.sfText_setOrigin(text, sfmlFloatPt(opts.Origin))
+		C.sfText_setScale(text, sfmlScale(opts.ScaleX, opts.ScaleY, opts.FlipX, opts.FlipY))
+		C.sfText_setRotation(text, C.float(opts.Rotation))
+		C.sfText_setFillColor(text, modulate(orig, opts))
+	}
+	C.sfText_setPosition(text, sfmlFloatPt(dp))
+	return orig
+}
+
+// ResetText resets the transformations and fill color of the text set by
+// SetText, restoring the original fill color orig.
+func ResetText(t unsafe.Pointer, orig color.NRGBA) {
+	text := (*C.sfText)(t)
+	C.sfText_setFillColor(text, C.sfColor{r: C.sfUint8(orig.R), g: C.sfUint8(orig.G), b: C.sfUint8(orig.B), a: C.sfUint8(orig.A)})
+	C.sfText_setOrigin(text, sfmlFloatPt(image.ZP))
+	C.sfText_setScale(text, sfmlScale(1, 1, false, false))
+	C.sfText_setRotation(text, 0)
+}
+
+// RenderStates returns a pointer to the SFML render states of the affine
+// transformation and blend mode of the provided draw options, or nil if the
+// default render states apply.
+func RenderStates(opts *Options) unsafe.Pointer {
+	if opts == nil || (opts.Transform == nil && opts.Blend == nil) {
+		return nil
+	}
+	states := &C.sfRenderStates{
+		blendMode: C.sfBlendAlpha,
+		transform: C.sfTransform_Identity,
+	}
+	if t := opts.Transform; t != nil {
+		states.transform = C.sfTransform_fromMatrix(
+			C.float(t[0]), C.float(t[1]), C.float(t[2]),
+			C.float(t[3]), C.float(t[4]), C.float(t[5]),
+			0, 0, 1)
+	}
+	if mode := opts.Blend; mode != nil {
+		states.blendMode = C.sfBlendMode{
+			colorSrcFactor: C.sfBlendFactor(mode.ColorSrc),
+			colorDstFactor: C.sfBlendFactor(mode.ColorDst),
+			colorEquation:  C.sfBlendEquation(mode.ColorEquation),
+			alphaSrcFactor: C.sfBlendFactor(mode.AlphaSrc),
+			alphaDstFactor: C.sfBlendFactor(mode.AlphaDst),
+			alphaEquation:  C.sfBlendEquation(mode.AlphaEquation),
+		}
+	}
+	return unsafe.Pointer(states)
+}
+
+// modulate returns the SFML color of c modulated by the color and fade of the
+// provided draw options.
+func modulate(c color.Color, opts *Options) C.sfColor {
+	src := color.NRGBAModel.Convert(c).(color.NRGBA)
+	mod := color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	if opts.Color != nil {
+		mod = color.NRGBAModel.Convert(opts.Color).(color.NRGBA)
+	}
+	fade := math.Max(0, math.Min(1, opts.Fade))
+	sfColor := C.sfColor{
+		r: C.sfUint8(uint(src.R) * uint(mod.R) / 0xFF),
+		g: C.sfUint8(uint(src.G) * uint(mod.G) / 0xFF),
+		b: C.sfUint8(uint(src.B) * uint(mod.B) / 0xFF),
+		a: C.sfUint8(math.Round(float64(uint(src.A)*uint(mod.A)/0xFF) * (1 - fade))),
+	}
+	return sfColor
+}
+
+// sfmlScale returns a SFML Vector2f of the provided scale factors, where 0
+// denotes no scaling, negated along flipped axes.
+func sfmlScale(sx, sy float64, flipX, flipY bool) C.sfVector2f {
+	if sx == 0 {
+		sx = 1
+	}
+	if sy == 0 {
+		sy = 1
+	}
+	if flipX {
+		sx = -sx
+	}
+	if flipY {
+		sy = -sy
+	}
+	return C.sfVector2f{x: C.float(sx), y: C.float(sy)}
+}
+
+// sfmlIntRect returns a SFML IntRect based on the provided Go image.Rectangle.
+func sfmlIntRect(r image.Rectangle) C.sfIntRect {
+	sfRect := C.sfIntRect{
+		left:   C.int(r.Min.X),
+		top:    C.int(r.Min.Y),
+		width:  C.int(r.Dx()),
+		height: C.int(r.Dy()),
+	}
+	return sfRect
+}
+
+// sfmlFloatPt returns a SFML Vector2f based on the provided Go image.Point.
+func sfmlFloatPt(pt image.Point) C.sfVector2f {
+	sfPt := C.sfVector2f{
+		x: C.float(pt.X),
+		y: C.float(pt.Y),
+	}
+	return sfPt
+}
@@ -0,0 +1,163 @@
+// Package glctx serializes the use of OpenGL contexts between goroutines.
+//
+// An OpenGL context may only be active in one OS thread at a time, and the
+// goroutines of a program migrate freely between OS threads. Render operations
+// therefore lock the calling goroutine to its OS thread and hold the lock of the
+// context of the render target while its OpenGL context is active.
+//
+// Activating a context is expensive, as SFML resets its cache of the OpenGL
+// states on activation. Within a frame, which lasts from the first draw
+// operation of a goroutine until the goroutine displays the frame, the context
+// is therefore kept active and the goroutine locked to its OS thread. The
+// context is only deactivated when the goroutine renders to another target, or
+// when the frame ends. Other goroutines which render to the same target wait
+// for the frame to end.
+package glctx
+
+// #include <pthread.h>
+// #include <stdint.h>
+//
+// static uintptr_t thread_id(void) {
+//    return (uintptr_t)pthread_self();
+// }
+import "C"
+
+import (
+	"runtime"
+	"sync"
+)
+
+// A Context tracks the activation of the OpenGL context of a render target.
+type Context struct {
+	// Activates or deactivates the OpenGL context in the calling thread.
+	setActive func(active bool)
+	// Held while the OpenGL context is active.
+	mu sync.Mutex
+}
+
+// NewContext returns a new context which uses setActive to activate and
+// deactivate the OpenGL context of a render target in the calling thread.
+func NewContext(setActive func(active bool)) *Context {
+	return &Context{setActive: setActive}
+}
+
+// thread records the OpenGL state of an OS thread. It is only accessed by the
+// goroutine locked to the thread.
+type thread struct {
+	// Context active in the thread; or nil if none.
+	active *Context
+	// Reports whether the goroutine locked to the thread renders a frame.
+	frame bool
+}
+
+var (
+	// mu protects threads.
+	mu sync.Mutex
+	// threads maps from OS thread IDs to the OpenGL state of the threads.
+	threads = make(map[C.uintptr_t]*thread)
+)
+
+// current returns the OpenGL state of the current OS thread. The calling
+// goroutine must be locked to its OS thread.
+func current() *thread {
+	id := C.thread_id()
+	mu.Lock()
+	defer mu.Unlock()
+	t, ok := threads[id]
+	if !ok {
+		t = &thread{}
+		threads[id] = t
+	}
+	return t
+}
+
+// Lock locks the calling goroutine to its current OS thread, acquires exclusive
+// access to the context and activates its OpenGL context, unless already active
+// in the thread. A context active in the thread for another render target is
+// deactivated first.
+func (ctx *Context) Lock() {
+	runtime.LockOSThread()
+	t := current()
+	if t.active == ctx {
+		return
+	}
+	t.deactivate()
+	ctx.mu.Lock()
+	ctx.setActive(true)
+	t.active = ctx
+}
+
+// Unlock unlocks the calling goroutine from its OS thread. Unless the goroutine
+// renders a frame, the OpenGL context of the context is deactivated and
+// exclusive access to the context released.
+func (ctx *Context) Unlock() {
+	if t := current(); !t.frame {
+		t.deactivate()
+	}
+	runtime.UnlockOSThread()
+}
+
+// Release deactivates the OpenGL context of the context, releases exclusive
+// access to the context and unlocks the calling goroutine from its OS thread,
+// even if the goroutine renders a frame. It is called in place of Unlock before
+// the render target of the context is destroyed.
+func (ctx *Context) Release() {
+	current().deactivate()
+	runtime.UnlockOSThread()
+}
+
+// BeginFrame marks the start of a frame rendered by the calling goroutine, which
+// keeps the contexts it activates active until EndFrame is called. The calling
+// goroutine remains locked to its OS thread until then.
+func BeginFrame() {
+	runtime.LockOSThread()
+	t := current()
+	if t.frame {
+		runtime.UnlockOSThread()
+		return
+	}
+	t.frame = true
+}
+
+// EndFrame marks the end of a frame rendered by the calling goroutine. It
+// deactivates the OpenGL context active in the thread, if any, and releases
+// exclusive access to its context. EndFrame is a no-op if the calling goroutine
+// renders no frame.
+func EndFrame() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	t := current()
+	t.deactivate()
+	if t.frame {
+		t.frame = false
+		runtime.UnlockOSThread()
+	}
+}
+
+// Lock locks the calling goroutine to its current OS thread, and deactivates the
+// OpenGL context active in the thread, if any, so that render targets may be
+// created or destroyed without affecting the contexts tracked by the thread.
+// Lock does not end the frame of the calling goroutine.
+func Lock() {
+	runtime.LockOSThread()
+	current().deactivate()
+}
+
+// Unlock unlocks the calling goroutine from its OS thread. The OpenGL contexts
+// activated since the call to Lock, e.g. on creation of a render target, must be
+// deactivated before calling Unlock.
+func Unlock() {
+	runtime.UnlockOSThread()
+}
+
+// deactivate deactivates the OpenGL context active in the thread, if any, and
+// releases exclusive access to its context.
+func (t *thread) deactivate() {
+	if t.active == nil {
+		return
+	}
+	ctx := t.active
+	t.active = nil
+	ctx.setActive(false)
+	ctx.mu.Unlock()
+}
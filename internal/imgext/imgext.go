@@ -0,0 +1,19 @@
+// Package imgext checks the file extensions of images saved by the texture and
+// window packages.
+package imgext
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Check checks that the file extension of the provided path denotes an image
+// format supported by SFML.
+func Check(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".bmp", ".tga":
+		return nil
+	}
+	return fmt.Errorf("unsupported image format of %q; expected .png, .jpg, .jpeg, .bmp or .tga extension", path)
+}
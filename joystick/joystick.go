@@ -0,0 +1,149 @@
+// Package joystick handles joystick and gamepad input. It uses a small subset
+// of the features provided by the SFML library version 2.5 [1].
+//
+// The state of the joysticks is updated automatically while events are polled
+// from an open window. Programs without a window must call Update before
+// querying the state of the joysticks.
+//
+// [1]: http://www.sfml-dev.org/
+package joystick
+
+// #include <SFML/Window.h>
+//
+// #cgo LDFLAGS: -lcsfml-window
+import "C"
+
+import (
+	"fmt"
+)
+
+// Joystick limits.
+const (
+	// MaxJoysticks is the maximum number of simultaneously connected joysticks.
+	MaxJoysticks = C.sfJoystickCount
+	// MaxButtons is the maximum number of buttons of a joystick.
+	MaxButtons = C.sfJoystickButtonCount
+	// MaxAxes is the maximum number of axes of a joystick.
+	MaxAxes = C.sfJoystickAxisCount
+)
+
+// Axis specifies a joystick axis.
+type Axis int
+
+// Joystick axes.
+const (
+	// X is the X axis.
+	X Axis = C.sfJoystickX
+	// Y is the Y axis.
+	Y Axis = C.sfJoystickY
+	// Z is the Z axis.
+	Z Axis = C.sfJoystickZ
+	// R is the R axis.
+	R Axis = C.sfJoystickR
+	// U is the U axis.
+	U Axis = C.sfJoystickU
+	// V is the V axis.
+	V Axis = C.sfJoystickV
+	// PovX is the X axis of the point-of-view hat.
+	PovX Axis = C.sfJoystickPovX
+	// PovY is the Y axis of the point-of-view hat.
+	PovY Axis = C.sfJoystickPovY
+)
+
+// axisNames maps from joystick axes to their names.
+var axisNames = map[Axis]string{
+	X:    "X",
+	Y:    "Y",
+	Z:    "Z",
+	R:    "R",
+	U:    "U",
+	V:    "V",
+	PovX: "PovX",
+	PovY: "PovY",
+}
+
+// String returns the name of the joystick axis.
+func (axis Axis) String() string {
+	if name, ok := axisNames[axis]; ok {
+		return name
+	}
+	return fmt.Sprintf("Axis(%d)", int(axis))
+}
+
+// A Device describes a connected joystick.
+type Device struct {
+	// Index of the joystick, in the range [0, MaxJoysticks).
+	ID int
+	// Name of the joystick.
+	Name string
+	// USB vendor ID of the joystick.
+	VendorID int
+	// USB product ID of the joystick.
+	ProductID int
+	// Number of buttons of the joystick.
+	Buttons int
+	// Axes supported by the joystick.
+	Axes []Axis
+}
+
+// Devices returns the connected joysticks.
+func Devices() []Device {
+	Update()
+	var devices []Device
+	for id := 0; id < MaxJoysticks; id++ {
+		if dev, ok := Lookup(id); ok {
+			devices = append(devices, dev)
+		}
+	}
+	return devices
+}
+
+// Lookup returns a description of the specified joystick. The boolean return
+// value indicates success; it is false if the joystick is not connected.
+func Lookup(id int) (Device, bool) {
+	if !Connected(id) {
+		return Device{}, false
+	}
+	info := C.sfJoystick_getIdentification(C.uint(id))
+	dev := Device{
+		ID:        id,
+		Name:      C.GoString(info.name),
+		VendorID:  int(info.vendorId),
+		ProductID: int(info.productId),
+		Buttons:   int(C.sfJoystick_getButtonCount(C.uint(id))),
+	}
+	for axis := Axis(0); axis < MaxAxes; axis++ {
+		if HasAxis(id, axis) {
+			dev.Axes = append(dev.Axes, axis)
+		}
+	}
+	return dev, true
+}
+
+// Connected reports whether the specified joystick is connected.
+func Connected(id int) bool {
+	return C.sfJoystick_isConnected(C.uint(id)) == C.sfTrue
+}
+
+// HasAxis reports whether the specified joystick supports the given axis.
+func HasAxis(id int, axis Axis) bool {
+	return C.sfJoystick_hasAxis(C.uint(id), C.sfJoystickAxis(axis)) == C.sfTrue
+}
+
+// Pressed reports whether the given button of the specified joystick is
+// pressed.
+func Pressed(id, button int) bool {
+	return C.sfJoystick_isButtonPressed(C.uint(id), C.uint(button)) == C.sfTrue
+}
+
+// Pos returns the current position, in the range [-100, 100], of the given
+// axis of the specified joystick.
+func Pos(id int, axis Axis) float64 {
+	return float64(C.sfJoystick_getAxisPosition(C.uint(id), C.sfJoystickAxis(axis)))
+}
+
+// Update updates the state of the joysticks. It is only required when no
+// window is polled for events.
+func Update() {
+	C.sfJoystick_update()
+}
@@ -0,0 +1,130 @@
+// Package mainthread provides a means to run functions on the main thread of
+// the program, as required by the window creation and event handling of some
+// operating systems.
+//
+// The main goroutine is locked to the main thread when the package is
+// initialized. A program which uses goroutines starts by passing its logic to
+// Run from the main goroutine, after which any goroutine may use Call to run
+// functions on the main thread.
+//
+//	func main() {
+//		mainthread.Run(run)
+//	}
+//
+//	func run() {
+//		// Window creation and event polling are routed through Call by the
+//		// window package, so it is safe to use from any goroutine.
+//		win, err := window.Open(640, 480)
+//		...
+//	}
+package mainthread
+
+// #include <pthread.h>
+//
+// static pthread_t main_thread;
+//
+// static void set_main_thread(void) {
+//    main_thread = pthread_self();
+// }
+//
+// static int is_main_thread(void) {
+//    return pthread_equal(main_thread, pthread_self());
+// }
+import "C"
+
+import (
+	"runtime"
+	"sync"
+)
+
+func init() {
+	// Package initialization takes place on the main thread. Lock the main
+	// goroutine to it.
+	runtime.LockOSThread()
+	C.set_main_thread()
+}
+
+// funcs holds functions to be called on the main thread.
+var funcs = make(chan func())
+
+var (
+	// mu protects done.
+	mu sync.Mutex
+	// done is closed when Run returns; or nil if Run is not active.
+	done chan struct{}
+)
+
+// Run calls run on a separate goroutine while serving calls to Call on the
+// main thread. It returns when run returns.
+//
+// Note: Run must be called from the main goroutine.
+func Run(run func()) {
+	if !OnMain() {
+		panic("mainthread.Run: must be called from the main goroutine")
+	}
+	mu.Lock()
+	if done != nil {
+		mu.Unlock()
+		panic("mainthread.Run: already running")
+	}
+	done = make(chan struct{})
+	mu.Unlock()
+	defer func() {
+		// Calls which are pending or made after Run returns are called
+		// directly.
+		mu.Lock()
+		close(done)
+		done = nil
+		mu.Unlock()
+	}()
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		run()
+	}()
+	for {
+		select {
+		case f := <-funcs:
+			f()
+		case <-finished:
+			return
+		}
+	}
+}
+
+// Call calls f on the main thread and waits for it to return. It is safe to
+// use from any goroutine. If Call is used from the main thread, f is called
+// directly.
+//
+// While Run is not active, f is called directly on the calling goroutine, and
+// thus on an arbitrary thread; this is also the case for calls which are still
+// pending when Run returns.
+func Call(f func()) {
+	if OnMain() {
+		f()
+		return
+	}
+	mu.Lock()
+	stop := done
+	mu.Unlock()
+	if stop == nil {
+		f()
+		return
+	}
+	ret := make(chan struct{})
+	call := func() {
+		defer close(ret)
+		f()
+	}
+	select {
+	case funcs <- call:
+		<-ret
+	case <-stop:
+		f()
+	}
+}
+
+// OnMain reports whether the current goroutine runs on the main thread.
+func OnMain() bool {
+	return C.is_main_thread() != 0
+}
@@ -0,0 +1,96 @@
+package texture
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import "github.com/mewspring/sfml/internal/drawopt"
+
+// BlendFactor specifies the factor by which the source or destination
+// components are multiplied when blending.
+type BlendFactor = drawopt.BlendFactor
+
+// Blend factors.
+const (
+	// FactorZero is (0, 0, 0, 0).
+	FactorZero BlendFactor = C.sfBlendFactorZero
+	// FactorOne is (1, 1, 1, 1).
+	FactorOne BlendFactor = C.sfBlendFactorOne
+	// FactorSrcColor is (src.r, src.g, src.b, src.a).
+	FactorSrcColor BlendFactor = C.sfBlendFactorSrcColor
+	// FactorOneMinusSrcColor is (1, 1, 1, 1) - (src.r, src.g, src.b, src.a).
+	FactorOneMinusSrcColor BlendFactor = C.sfBlendFactorOneMinusSrcColor
+	// FactorDstColor is (dst.r, dst.g, dst.b, dst.a).
+	FactorDstColor BlendFactor = C.sfBlendFactorDstColor
+	// FactorOneMinusDstColor is (1, 1, 1, 1) - (dst.r, dst.g, dst.b, dst.a).
+	FactorOneMinusDstColor BlendFactor = C.sfBlendFactorOneMinusDstColor
+	// FactorSrcAlpha is (src.a, src.a, src.a, src.a).
+	FactorSrcAlpha BlendFactor = C.sfBlendFactorSrcAlpha
+	// FactorOneMinusSrcAlpha is (1, 1, 1, 1) - (src.a, src.a, src.a, src.a).
+	FactorOneMinusSrcAlpha BlendFactor = C.sfBlendFactorOneMinusSrcAlpha
+	// FactorDstAlpha is (dst.a, dst.a, dst.a, dst.a).
+	FactorDstAlpha BlendFactor = C.sfBlendFactorDstAlpha
+	// FactorOneMinusDstAlpha is (1, 1, 1, 1) - (dst.a, dst.a, dst.a, dst.a).
+	FactorOneMinusDstAlpha BlendFactor = C.sfBlendFactorOneMinusDstAlpha
+)
+
+// BlendEquation specifies how the multiplied source and destination components
+// are combined when blending.
+type BlendEquation = drawopt.BlendEquation
+
+// Blend equations.
+const (
+	// EquationAdd is src*srcFactor + dst*dstFactor.
+	EquationAdd BlendEquation = C.sfBlendEquationAdd
+	// EquationSubtract is src*srcFactor - dst*dstFactor.
+	EquationSubtract BlendEquation = C.sfBlendEquationSubtract
+	// EquationReverseSubtract is dst*dstFactor - src*srcFactor.
+	EquationReverseSubtract BlendEquation = C.sfBlendEquationReverseSubtract
+)
+
+// A BlendMode specifies how the colors of drawn pixels are blended with the
+// colors of the destination pixels. The color and alpha components are blended
+// separately. See drawopt.BlendMode for its fields.
+type BlendMode = drawopt.BlendMode
+
+// Blend mode presets.
+var (
+	// BlendAlpha blends pixels based on the alpha of the source, which is the
+	// default blend mode.
+	BlendAlpha = BlendMode{
+		ColorSrc:      FactorSrcAlpha,
+		ColorDst:      FactorOneMinusSrcAlpha,
+		ColorEquation: EquationAdd,
+		AlphaSrc:      FactorOne,
+		AlphaDst:      FactorOneMinusSrcAlpha,
+		AlphaEquation: EquationAdd,
+	}
+	// BlendAdd adds the source pixels to the destination pixels; e.g. for
+	// particles and light maps.
+	BlendAdd = BlendMode{
+		ColorSrc:      FactorSrcAlpha,
+		ColorDst:      FactorOne,
+		ColorEquation: EquationAdd,
+		AlphaSrc:      FactorOne,
+		AlphaDst:      FactorOne,
+		AlphaEquation: EquationAdd,
+	}
+	// BlendMultiply multiplies the destination pixels by the source pixels.
+	BlendMultiply = BlendMode{
+		ColorSrc:      FactorDstColor,
+		ColorDst:      FactorZero,
+		ColorEquation: EquationAdd,
+		AlphaSrc:      FactorDstColor,
+		AlphaDst:      FactorZero,
+		AlphaEquation: EquationAdd,
+	}
+	// BlendNone replaces the destination pixels by the source pixels, including
+	// their alpha; e.g. for cutting holes into masks.
+	BlendNone = BlendMode{
+		ColorSrc:      FactorOne,
+		ColorDst:      FactorZero,
+		ColorEquation: EquationAdd,
+		AlphaSrc:      FactorOne,
+		AlphaDst:      FactorZero,
+		AlphaEquation: EquationAdd,
+	}
+)
@@ -1,5 +1,6 @@
 package texture
 
+// #include <stdlib.h>
 // #include <string.h>
 // #include <SFML/Graphics.h>
 import "C"
@@ -9,9 +10,14 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
 	"unsafe"
 
 	"github.com/mewspring/sfml/font"
+	"github.com/mewspring/sfml/internal/drawopt"
+	"github.com/mewspring/sfml/internal/glctx"
+	"github.com/mewspring/sfml/internal/imgext"
+	"github.com/mewspring/sfml/view"
 	"github.com/mewspring/wandi"
 )
 
@@ -22,6 +28,8 @@ type Drawable struct {
 	tex *C.sfRenderTexture
 	// A sprite representation of the GPU texture.
 	sprite *C.sfSprite
+	// OpenGL context of the rendering texture.
+	ctx *glctx.Context
 }
 
 // NewDrawable creates a drawable texture of the specified dimensions.
@@ -29,13 +37,26 @@ type Drawable struct {
 // Note: The Free method of the texture must be called when finished using it.
 func NewDrawable(width, height int) (*Drawable, error) {
 	// Create a rendering texture of the specified dimensions.
+	glctx.Lock()
 	t := C.sfRenderTexture_create(C.uint(width), C.uint(height), C.sfFalse)
 	if t == nil {
+		glctx.Unlock()
 		return nil, fmt.Errorf("texture.NewDrawable: unable to create %dx%d rendering texture", width, height)
 	}
+	// Deactivate the OpenGL context of the rendering texture, which is activated
+	// on creation.
+	C.sfRenderTexture_setActive(t, C.sfFalse)
+	glctx.Unlock()
 	tex := &Drawable{
 		tex: t,
 	}
+	tex.ctx = glctx.NewContext(func(active bool) {
+		if active {
+			C.sfRenderTexture_setActive(tex.tex, C.sfTrue)
+		} else {
+			C.sfRenderTexture_setActive(tex.tex, C.sfFalse)
+		}
+	})
 	// Create a sprite for the rendering texture.
 	sprite := C.sfSprite_create()
 	if sprite == nil {
@@ -96,8 +117,30 @@ func ReadDrawable(img image.Image) (*Drawable, error) {
 	return tex, nil
 }
 
+// lock locks the calling goroutine to its OS thread and acquires exclusive
+// access to the OpenGL context of the texture, which is activated. Rendering to
+// the texture from several goroutines is thereby safe.
+//
+// A goroutine which renders a frame to a window keeps the OpenGL context of the
+// texture active until the frame is displayed, or until the goroutine renders
+// to another target.
+func (tex *Drawable) lock() {
+	tex.ctx.Lock()
+}
+
+// unlock releases exclusive access to the OpenGL context of the texture, which
+// is deactivated unless the calling goroutine renders a frame.
+func (tex *Drawable) unlock() {
+	tex.ctx.Unlock()
+}
+
 // Free frees the texture.
 func (tex *Drawable) Free() {
+	// Wait for other goroutines to finish rendering to the texture.
+	tex.ctx.Lock()
+	tex.ctx.Release()
+	glctx.Lock()
+	defer glctx.Unlock()
 	C.sfSprite_destroy(tex.sprite)
 	C.sfRenderTexture_destroy(tex.tex)
 }
@@ -124,36 +167,99 @@ func (dst *Drawable) Draw(dp image.Point, src wandi.Image) error {
 // DrawRect draws a subset of the src image, as defined by the source rectangle
 // sr, onto the dst texture starting at the destination point dp.
 func (dst *Drawable) DrawRect(dp image.Point, src wandi.Image, sr image.Rectangle) error {
+	if err := dst.drawRect(dp, src, sr, nil); err != nil {
+		return fmt.Errorf("Drawable.DrawRect: %v", err)
+	}
+	return nil
+}
+
+// DrawRectOptions draws a subset of the src image, as defined by the source
+// rectangle sr, onto the dst texture at the destination point dp, transformed
+// as specified by the provided draw options. A nil opts is equivalent to
+// DrawRect.
+func (dst *Drawable) DrawRectOptions(dp image.Point, src wandi.Image, sr image.Rectangle, opts *DrawOptions) error {
+	if err := dst.drawRect(dp, src, sr, opts); err != nil {
+		return fmt.Errorf("Drawable.DrawRectOptions: %v", err)
+	}
+	return nil
+}
+
+// drawRect draws a subset of the src image, as defined by the source rectangle
+// sr, onto the dst texture at the destination point dp, transformed as
+// specified by the provided draw options.
+func (dst *Drawable) drawRect(dp image.Point, src wandi.Image, sr image.Rectangle, opts *DrawOptions) error {
+	dst.lock()
+	defer dst.unlock()
+	states := (*C.sfRenderStates)(drawopt.RenderStates(opts))
 	switch srcImg := src.(type) {
 	case *Drawable:
-		C.sfSprite_setTextureRect(srcImg.sprite, sfmlIntRect(sr))
-		C.sfSprite_setPosition(srcImg.sprite, sfmlFloatPt(dp))
-		C.sfRenderTexture_drawSprite(dst.tex, srcImg.sprite, nil)
+		drawopt.SetSprite(unsafe.Pointer(srcImg.sprite), dp, sr, opts)
+		C.sfRenderTexture_drawSprite(dst.tex, srcImg.sprite, states)
+		drawopt.ResetSprite(unsafe.Pointer(srcImg.sprite))
 		C.sfRenderTexture_display(dst.tex)
 	case *Image:
-		C.sfSprite_setTextureRect(srcImg.sprite, sfmlIntRect(sr))
-		C.sfSprite_setPosition(srcImg.sprite, sfmlFloatPt(dp))
-		C.sfRenderTexture_drawSprite(dst.tex, srcImg.sprite, nil)
+		drawopt.SetSprite(unsafe.Pointer(srcImg.sprite), dp, sr, opts)
+		C.sfRenderTexture_drawSprite(dst.tex, srcImg.sprite, states)
+		drawopt.ResetSprite(unsafe.Pointer(srcImg.sprite))
 		C.sfRenderTexture_display(dst.tex)
 	case *font.Text:
 		// TODO(u): Handle sr?
 		text := textText(srcImg)
-		C.sfText_setPosition(text, sfmlFloatPt(dp))
-		C.sfRenderTexture_drawText(dst.tex, text, nil)
+		orig := drawopt.SetText(unsafe.Pointer(text), dp, opts)
+		C.sfRenderTexture_drawText(dst.tex, text, states)
+		drawopt.ResetText(unsafe.Pointer(text), orig)
 		C.sfRenderTexture_display(dst.tex)
 	default:
-		return fmt.Errorf("Drawable.DrawRect: support for image format %T not yet implemented", src)
+		return fmt.Errorf("support for image format %T not yet implemented", src)
 	}
 	return nil
 }
 
 // Fill fills the entire texture with the provided color.
 func (dst *Drawable) Fill(c color.Color) {
+	dst.lock()
+	defer dst.unlock()
 	C.sfRenderTexture_clear(dst.tex, sfmlColor(c))
 }
 
+// SetView sets the view of the texture, which defines the region of the world
+// shown by subsequent draw operations. A nil view resets the texture to its
+// default view, which shows the entire texture.
+//
+// Note: The texture stores a copy of the view. After modifying the view, it must
+// be set again for the changes to take effect.
+func (dst *Drawable) SetView(v *view.View) {
+	if v == nil {
+		C.sfRenderTexture_setView(dst.tex, C.sfRenderTexture_getDefaultView(dst.tex))
+		return
+	}
+	C.sfRenderTexture_setView(dst.tex, viewView(v))
+}
+
+// MapPixelToCoords converts the provided pixel position of the texture to world
+// coordinates, using the provided view. A nil view denotes the current view of
+// the texture.
+func (dst *Drawable) MapPixelToCoords(pt image.Point, v *view.View) image.Point {
+	pixelPos := C.sfVector2i{
+		x: C.int(pt.X),
+		y: C.int(pt.Y),
+	}
+	coordPos := C.sfRenderTexture_mapPixelToCoords(dst.tex, pixelPos, viewView(v))
+	return image.Pt(int(math.Round(float64(coordPos.x))), int(math.Round(float64(coordPos.y))))
+}
+
+// MapCoordsToPixel converts the provided world coordinates to a pixel position
+// of the texture, using the provided view. A nil view denotes the current view
+// of the texture.
+func (dst *Drawable) MapCoordsToPixel(pt image.Point, v *view.View) image.Point {
+	pixelPos := C.sfRenderTexture_mapCoordsToPixel(dst.tex, sfmlFloatPt(pt), viewView(v))
+	return image.Pt(int(pixelPos.x), int(pixelPos.y))
+}
+
 // Image returns an image.Image representation of the texture.
 func (tex *Drawable) Image() (image.Image, error) {
+	tex.lock()
+	defer tex.unlock()
 	// Copy the rendering texture to a SFML image.
 	sfImg := C.sfTexture_copyToImage(tex.texture())
 	if sfImg == nil {
@@ -170,3 +276,25 @@ func (tex *Drawable) Image() (image.Image, error) {
 	C.memcpy(unsafe.Pointer(&dst.Pix[0]), unsafe.Pointer(pix), C.size_t(len(dst.Pix)))
 	return dst, nil
 }
+
+// Save saves the contents of the drawable texture to the provided image file.
+// The image format is determined by the file extension; PNG, JPEG, BMP and TGA
+// are supported.
+func (tex *Drawable) Save(path string) error {
+	if err := imgext.Check(path); err != nil {
+		return fmt.Errorf("Drawable.Save: %v", err)
+	}
+	tex.lock()
+	defer tex.unlock()
+	sfImg := C.sfTexture_copyToImage(tex.texture())
+	if sfImg == nil {
+		return errors.New("Drawable.Save: unable to create image from texture")
+	}
+	defer C.sfImage_destroy(sfImg)
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	if C.sfImage_saveToFile(sfImg, cPath) == C.sfFalse {
+		return fmt.Errorf("Drawable.Save: unable to save image %q", path)
+	}
+	return nil
+}
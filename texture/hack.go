@@ -7,6 +7,7 @@ import (
 	"unsafe"
 
 	"github.com/mewspring/sfml/font"
+	"github.com/mewspring/sfml/view"
 )
 
 // textHack is a copy of font.Text without modifications. Through the use of
@@ -22,3 +23,20 @@ type textHack struct {
 func textText(text *font.Text) *C.sfText {
 	return (*textHack)(unsafe.Pointer(text)).text
 }
+
+// viewHack is a copy of view.View without modifications. Through the use of
+// unsafe and with knowledge of its memory layout we are able to access
+// unexported members. This hack allows us to cross package barriers while
+// keeping the exported API clean.
+type viewHack struct {
+	// A 2D camera.
+	view *C.sfView
+}
+
+// viewView returns the view of the provided view.View, or nil if v is nil.
+func viewView(v *view.View) *C.sfView {
+	if v == nil {
+		return nil
+	}
+	return (*viewHack)(unsafe.Pointer(v)).view
+}
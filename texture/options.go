@@ -0,0 +1,14 @@
+package texture
+
+import "github.com/mewspring/sfml/internal/drawopt"
+
+// DrawOptions specifies optional transformations, color modulation and blending
+// of a draw operation. The zero value draws the source image unmodified, using
+// alpha blending. See drawopt.Options for its fields.
+type DrawOptions = drawopt.Options
+
+// A Transform is a 2D affine transformation, specified by the first two rows of
+// a 3x3 matrix in row-major order. The transformation maps the point (x, y) to
+// (a*x + b*y + c, d*x + e*y + f), where [a, b, c, d, e, f] are the elements of
+// the transform.
+type Transform = drawopt.Transform
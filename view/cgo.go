@@ -0,0 +1,29 @@
+package view
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"image"
+)
+
+// sfmlVector returns a SFML Vector2f based on the provided coordinates.
+func sfmlVector(x, y float64) C.sfVector2f {
+	sfPt := C.sfVector2f{
+		x: C.float(x),
+		y: C.float(y),
+	}
+	return sfPt
+}
+
+// sfmlFloatRect returns a SFML FloatRect based on the provided Go
+// image.Rectangle.
+func sfmlFloatRect(r image.Rectangle) C.sfFloatRect {
+	sfRect := C.sfFloatRect{
+		left:   C.float(r.Min.X),
+		top:    C.float(r.Min.Y),
+		width:  C.float(r.Dx()),
+		height: C.float(r.Dy()),
+	}
+	return sfRect
+}
@@ -0,0 +1,140 @@
+// Package view handles 2D cameras which define the region of the world shown by
+// a render target. It uses a small subset of the features provided by the SFML
+// library version 2.5 [1].
+//
+// Views make it possible to implement scrolling cameras, split-screen and
+// minimaps without offsetting every draw operation by hand.
+//
+// [1]: http://www.sfml-dev.org/
+package view
+
+// #include <SFML/Graphics.h>
+//
+// #cgo LDFLAGS: -lcsfml-graphics
+import "C"
+
+import (
+	"errors"
+	"image"
+)
+
+// A View represents a 2D camera. It defines the rectangular region of the world
+// which is shown, and the region of the render target (the viewport) onto which
+// it is shown.
+//
+// Note: Render targets store a copy of the view. After modifying a view, it
+// must be set again on the render target for the changes to take effect.
+type View struct {
+	// A 2D camera.
+	view *C.sfView
+}
+
+// New returns a new view which shows the provided region of the world. The
+// view is shown on the entire render target.
+//
+// Note: The Free method of the view must be called when finished using it.
+func New(r image.Rectangle) (*View, error) {
+	v := C.sfView_createFromRect(sfmlFloatRect(r))
+	if v == nil {
+		return nil, errors.New("view.New: unable to create view")
+	}
+	view := &View{
+		view: v,
+	}
+	return view, nil
+}
+
+// Copy returns a copy of the view.
+//
+// Note: The Free method of the view must be called when finished using it.
+func (view *View) Copy() (*View, error) {
+	v := C.sfView_copy(view.view)
+	if v == nil {
+		return nil, errors.New("View.Copy: unable to copy view")
+	}
+	dup := &View{
+		view: v,
+	}
+	return dup, nil
+}
+
+// Free frees the view.
+func (view *View) Free() {
+	C.sfView_destroy(view.view)
+}
+
+// Reset resets the view to show the provided region of the world. The
+// rotation of the view is reset to zero.
+func (view *View) Reset(r image.Rectangle) {
+	C.sfView_reset(view.view, sfmlFloatRect(r))
+}
+
+// Center returns the center of the view, in world coordinates.
+func (view *View) Center() (x, y float64) {
+	center := C.sfView_getCenter(view.view)
+	return float64(center.x), float64(center.y)
+}
+
+// SetCenter sets the center of the view, in world coordinates.
+func (view *View) SetCenter(x, y float64) {
+	C.sfView_setCenter(view.view, sfmlVector(x, y))
+}
+
+// Move moves the center of the view by the provided offset.
+func (view *View) Move(dx, dy float64) {
+	C.sfView_move(view.view, sfmlVector(dx, dy))
+}
+
+// Size returns the size of the view, in world coordinates.
+func (view *View) Size() (width, height float64) {
+	size := C.sfView_getSize(view.view)
+	return float64(size.x), float64(size.y)
+}
+
+// SetSize sets the size of the view, in world coordinates.
+func (view *View) SetSize(width, height float64) {
+	C.sfView_setSize(view.view, sfmlVector(width, height))
+}
+
+// Zoom resizes the view relative to its current size. A factor above 1 zooms
+// out and a factor below 1 zooms in.
+func (view *View) Zoom(factor float64) {
+	C.sfView_zoom(view.view, C.float(factor))
+}
+
+// Rotation returns the rotation of the view, in degrees.
+func (view *View) Rotation() float64 {
+	return float64(C.sfView_getRotation(view.view))
+}
+
+// SetRotation sets the rotation of the view, in degrees.
+func (view *View) SetRotation(angle float64) {
+	C.sfView_setRotation(view.view, C.float(angle))
+}
+
+// Rotate rotates the view relative to its current rotation, in degrees.
+func (view *View) Rotate(angle float64) {
+	C.sfView_rotate(view.view, C.float(angle))
+}
+
+// Viewport returns the viewport of the view; i.e. the region of the render
+// target onto which the view is shown, in normalized coordinates ranging from
+// 0 to 1.
+func (view *View) Viewport() (x, y, width, height float64) {
+	vp := C.sfView_getViewport(view.view)
+	return float64(vp.left), float64(vp.top), float64(vp.width), float64(vp.height)
+}
+
+// SetViewport sets the viewport of the view; i.e. the region of the render
+// target onto which the view is shown, in normalized coordinates ranging from
+// 0 to 1. For instance, the left half of a split-screen is specified by the
+// viewport (0, 0, 0.5, 1).
+func (view *View) SetViewport(x, y, width, height float64) {
+	vp := C.sfFloatRect{
+		left:   C.float(x),
+		top:    C.float(y),
+		width:  C.float(width),
+		height: C.float(height),
+	}
+	C.sfView_setViewport(view.view, vp)
+}
@@ -0,0 +1,89 @@
+package window
+
+// #include <stdlib.h>
+// #include <string.h>
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"unsafe"
+
+	"github.com/mewspring/sfml/internal/imgext"
+)
+
+// Capture returns a copy of the current contents of the window.
+//
+// Note: Capture should be called before Display, as the contents of the window
+// are undefined after the frame has been displayed.
+func (win *Window) Capture() (image.Image, error) {
+	if win.isClosed() {
+		return nil, errors.New("Window.Capture: window closed")
+	}
+	win.lock()
+	defer win.unlock()
+	sfImg, err := win.capture()
+	if err != nil {
+		return nil, fmt.Errorf("Window.Capture: %v", err)
+	}
+	defer C.sfImage_destroy(sfImg)
+	// Create a Go RGBA image based on the pixels of the SFML image.
+	pix := C.sfImage_getPixelsPtr(sfImg)
+	if pix == nil {
+		return nil, errors.New("Window.Capture: unable to locate image pixels")
+	}
+	size := C.sfImage_getSize(sfImg)
+	dst := image.NewRGBA(image.Rect(0, 0, int(size.x), int(size.y)))
+	C.memcpy(unsafe.Pointer(&dst.Pix[0]), unsafe.Pointer(pix), C.size_t(len(dst.Pix)))
+	return dst, nil
+}
+
+// Save saves the current contents of the window to the provided image file.
+// The image format is determined by the file extension; PNG, JPEG, BMP and TGA
+// are supported.
+//
+// Note: Save should be called before Display, as the contents of the window are
+// undefined after the frame has been displayed.
+func (win *Window) Save(path string) error {
+	if err := imgext.Check(path); err != nil {
+		return fmt.Errorf("Window.Save: %v", err)
+	}
+	if win.isClosed() {
+		return errors.New("Window.Save: window closed")
+	}
+	win.lock()
+	defer win.unlock()
+	sfImg, err := win.capture()
+	if err != nil {
+		return fmt.Errorf("Window.Save: %v", err)
+	}
+	defer C.sfImage_destroy(sfImg)
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	if C.sfImage_saveToFile(sfImg, cPath) == C.sfFalse {
+		return fmt.Errorf("Window.Save: unable to save image %q", path)
+	}
+	return nil
+}
+
+// capture returns a SFML image of the current contents of the window. The
+// caller is responsible for destroying the image.
+func (win *Window) capture() (*C.sfImage, error) {
+	size := C.sfRenderWindow_getSize(win.win)
+	if size.x == 0 || size.y == 0 {
+		return nil, errors.New("unable to capture empty window")
+	}
+	tex := C.sfTexture_create(size.x, size.y)
+	if tex == nil {
+		return nil, errors.New("unable to create texture")
+	}
+	defer C.sfTexture_destroy(tex)
+	C.sfTexture_updateFromRenderWindow(tex, win.win, 0, 0)
+	sfImg := C.sfTexture_copyToImage(tex)
+	if sfImg == nil {
+		return nil, errors.New("unable to create image from texture")
+	}
+	return sfImg, nil
+}
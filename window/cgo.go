@@ -6,6 +6,7 @@ import "C"
 import (
 	"image"
 	"image/color"
+	"unsafe"
 )
 
 // sfmlColor returns a SFML Color based on the provided Go color.Color.
@@ -40,6 +41,15 @@ func sfmlFloatPt(pt image.Point) C.sfVector2f {
 	return sfPt
 }
 
+// sfmlIntPt returns a SFML Vector2i based on the provided Go image.Point.
+func sfmlIntPt(pt image.Point) C.sfVector2i {
+	sfPt := C.sfVector2i{
+		x: C.int(pt.X),
+		y: C.int(pt.Y),
+	}
+	return sfPt
+}
+
 // sfmlBool returns a SFML boolean based on the provided Go bool.
 func sfmlBool(b bool) C.sfBool {
 	if b {
@@ -58,3 +68,19 @@ func utf32(s string) *C.sfUint32 {
 	s32 = append(s32, 0)
 	return &s32[0]
 }
+
+// goString returns the Go string representation of the provided NULL-terminated
+// UTF-32 string.
+func goString(s32 *C.sfUint32) string {
+	if s32 == nil {
+		return ""
+	}
+	var runes []rune
+	for _, r := range (*[1 << 28]C.sfUint32)(unsafe.Pointer(s32)) {
+		if r == 0 {
+			break
+		}
+		runes = append(runes, rune(r))
+	}
+	return string(runes)
+}
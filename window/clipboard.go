@@ -0,0 +1,15 @@
+package window
+
+// #include <SFML/Window.h>
+import "C"
+
+// Clipboard returns the text contents of the system clipboard. The clipboard is
+// shared by all windows, and may be used without opening a window.
+func Clipboard() string {
+	return goString(C.sfClipboard_getUnicodeString())
+}
+
+// SetClipboard sets the text contents of the system clipboard.
+func SetClipboard(s string) {
+	C.sfClipboard_setUnicodeString(utf32(s))
+}
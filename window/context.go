@@ -0,0 +1,67 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+// ContextSettings specifies the settings of the OpenGL context attached to a
+// window. The zero value of each field requests the driver default.
+type ContextSettings struct {
+	// Bits of the depth buffer.
+	DepthBits int
+	// Bits of the stencil buffer.
+	StencilBits int
+	// Level of antialiasing; i.e. the number of multisampling (MSAA) samples.
+	AntialiasingLevel int
+	// Major version number of the requested OpenGL context.
+	MajorVersion int
+	// Minor version number of the requested OpenGL context.
+	MinorVersion int
+	// Attributes of the requested OpenGL context.
+	Attributes ContextAttr
+	// Specifies whether the context should be sRGB capable.
+	SRGBCapable bool
+}
+
+// ContextAttr is a bitfield which represents the attributes of an OpenGL
+// context.
+type ContextAttr uint32
+
+// OpenGL context attributes.
+const (
+	// ContextDefault requests a non-debug, compatibility context.
+	ContextDefault ContextAttr = C.sfContextDefault
+	// ContextCore requests a core profile context.
+	ContextCore ContextAttr = C.sfContextCore
+	// ContextDebug requests a debug context.
+	ContextDebug ContextAttr = C.sfContextDebug
+)
+
+// sfmlContextSettings returns a SFML ContextSettings based on the provided Go
+// ContextSettings.
+func sfmlContextSettings(settings ContextSettings) C.sfContextSettings {
+	sfSettings := C.sfContextSettings{
+		depthBits:         C.uint(settings.DepthBits),
+		stencilBits:       C.uint(settings.StencilBits),
+		antialiasingLevel: C.uint(settings.AntialiasingLevel),
+		majorVersion:      C.uint(settings.MajorVersion),
+		minorVersion:      C.uint(settings.MinorVersion),
+		attributeFlags:    C.sfUint32(settings.Attributes),
+		sRgbCapable:       sfmlBool(settings.SRGBCapable),
+	}
+	return sfSettings
+}
+
+// goContextSettings returns a Go ContextSettings based on the provided SFML
+// ContextSettings.
+func goContextSettings(sfSettings C.sfContextSettings) ContextSettings {
+	settings := ContextSettings{
+		DepthBits:         int(sfSettings.depthBits),
+		StencilBits:       int(sfSettings.stencilBits),
+		AntialiasingLevel: int(sfSettings.antialiasingLevel),
+		MajorVersion:      int(sfSettings.majorVersion),
+		MinorVersion:      int(sfSettings.minorVersion),
+		Attributes:        ContextAttr(sfSettings.attributeFlags),
+		SRGBCapable:       sfSettings.sRgbCapable == C.sfTrue,
+	}
+	return settings
+}
@@ -0,0 +1,136 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"unsafe"
+)
+
+// A Cursor represents the graphical representation of a mouse cursor.
+type Cursor struct {
+	// A mouse cursor.
+	cursor *C.sfCursor
+}
+
+// CursorType specifies the type of a system cursor.
+type CursorType int
+
+// System cursor types.
+const (
+	// CursorArrow is the arrow cursor (default).
+	CursorArrow CursorType = C.sfCursorArrow
+	// CursorArrowWait is the busy arrow cursor.
+	CursorArrowWait CursorType = C.sfCursorArrowWait
+	// CursorWait is the busy cursor.
+	CursorWait CursorType = C.sfCursorWait
+	// CursorText is the I-beam cursor used for text selection.
+	CursorText CursorType = C.sfCursorText
+	// CursorHand is the pointing hand cursor.
+	CursorHand CursorType = C.sfCursorHand
+	// CursorSizeHorizontal is the horizontal double arrow cursor.
+	CursorSizeHorizontal CursorType = C.sfCursorSizeHorizontal
+	// CursorSizeVertical is the vertical double arrow cursor.
+	CursorSizeVertical CursorType = C.sfCursorSizeVertical
+	// CursorSizeTopLeftBottomRight is the double arrow cursor going from
+	// top-left to bottom-right.
+	CursorSizeTopLeftBottomRight CursorType = C.sfCursorSizeTopLeftBottomRight
+	// CursorSizeBottomLeftTopRight is the double arrow cursor going from
+	// bottom-left to top-right.
+	CursorSizeBottomLeftTopRight CursorType = C.sfCursorSizeBottomLeftTopRight
+	// CursorSizeAll is the combination of the horizontal and vertical double
+	// arrow cursors.
+	CursorSizeAll CursorType = C.sfCursorSizeAll
+	// CursorCross is the crosshair cursor.
+	CursorCross CursorType = C.sfCursorCross
+	// CursorHelp is the help cursor.
+	CursorHelp CursorType = C.sfCursorHelp
+	// CursorNotAllowed is the action not allowed cursor.
+	CursorNotAllowed CursorType = C.sfCursorNotAllowed
+)
+
+// NewCursor creates a mouse cursor based on the provided image. The hotspot
+// specifies the point of the image, relative to its top-left corner, which
+// represents the position of the cursor.
+//
+// Note: The Free method of the cursor must be called when finished using it.
+func NewCursor(img image.Image, hotspot image.Point) (*Cursor, error) {
+	src := nrgba(img)
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	if width == 0 || height == 0 {
+		return nil, errors.New("window.NewCursor: empty cursor image")
+	}
+	if !hotspot.In(image.Rect(0, 0, width, height)) {
+		return nil, fmt.Errorf("window.NewCursor: hotspot %v outside of %dx%d cursor image", hotspot, width, height)
+	}
+	pix := (*C.sfUint8)(unsafe.Pointer(&src.Pix[0]))
+	size := C.sfVector2u{
+		x: C.uint(width),
+		y: C.uint(height),
+	}
+	hot := C.sfVector2u{
+		x: C.uint(hotspot.X),
+		y: C.uint(hotspot.Y),
+	}
+	c := C.sfCursor_createFromPixels(pix, size, hot)
+	if c == nil {
+		return nil, fmt.Errorf("window.NewCursor: unable to create %dx%d cursor", width, height)
+	}
+	cursor := &Cursor{
+		cursor: c,
+	}
+	return cursor, nil
+}
+
+// NewSystemCursor creates a mouse cursor of the specified system cursor type.
+//
+// Note: The Free method of the cursor must be called when finished using it.
+func NewSystemCursor(typ CursorType) (*Cursor, error) {
+	c := C.sfCursor_createFromSystem(C.sfCursorType(typ))
+	if c == nil {
+		return nil, fmt.Errorf("window.NewSystemCursor: system cursor type %d not supported", typ)
+	}
+	cursor := &Cursor{
+		cursor: c,
+	}
+	return cursor, nil
+}
+
+// Free frees the cursor.
+//
+// Note: The cursor must not be freed while in use by a window.
+func (cursor *Cursor) Free() {
+	C.sfCursor_destroy(cursor.cursor)
+}
+
+// arrowCursor is the system arrow cursor, used to restore the default cursor of
+// windows; created on first use and never freed.
+var (
+	arrowCursor     *C.sfCursor
+	arrowCursorOnce sync.Once
+)
+
+// SetCursor sets the mouse cursor of the window. The cursor must remain valid,
+// i.e. not be freed, as long as it is used by the window. A nil cursor restores
+// the default cursor; i.e. the system arrow cursor, if supported by the
+// operating system.
+func (win *Window) SetCursor(cursor *Cursor) {
+	win.cursor = cursor
+	if cursor == nil {
+		arrowCursorOnce.Do(func() {
+			arrowCursor = C.sfCursor_createFromSystem(C.sfCursorArrow)
+		})
+		if arrowCursor == nil {
+			// The system arrow cursor is not supported; keep the current
+			// cursor.
+			return
+		}
+		C.sfRenderWindow_setMouseCursor(win.win, arrowCursor)
+		return
+	}
+	C.sfRenderWindow_setMouseCursor(win.win, cursor.cursor)
+}
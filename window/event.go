@@ -26,29 +26,94 @@ package window
 //    return e.mouseButton;
 // }
 //
+// sfMouseWheelEvent getMouseWheelEvent(sfEvent e) {
+//    return e.mouseWheel;
+// }
+//
 // sfMouseWheelScrollEvent getMouseWheelScrollEvent(sfEvent e) {
 //    return e.mouseWheelScroll;
 // }
+//
+// sfJoystickButtonEvent getJoystickButtonEvent(sfEvent e) {
+//    return e.joystickButton;
+// }
+//
+// sfJoystickMoveEvent getJoystickMoveEvent(sfEvent e) {
+//    return e.joystickMove;
+// }
+//
+// sfJoystickConnectEvent getJoystickConnectEvent(sfEvent e) {
+//    return e.joystickConnect;
+// }
+//
+// sfTouchEvent getTouchEvent(sfEvent e) {
+//    return e.touch;
+// }
+//
+// sfSensorEvent getSensorEvent(sfEvent e) {
+//    return e.sensor;
+// }
 import "C"
 
 import (
 	"image"
 	"log"
 
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/sfml/mainthread"
 	"github.com/mewspring/we"
 )
 
 // PollEvent returns a pending event from the event queue or nil if the queue
 // was empty. Note that more than one event may be present in the event queue.
+// No events are returned once the window is closed.
+//
+// SFML events without a we counterpart, such as focus, joystick, touch and
+// sensor events, are returned as event types of this package; e.g. Focus.
 //
-// Note: The main thread must be used for both window creation and event
-// handling. It is perfectly fine to use separate threads for rendering and
-// event handling, as long as all event handling takes place in the main thread.
+// Note: Event handling takes place on the main thread, as required by some
+// operating systems. Programs which poll events from other goroutines than the
+// main goroutine must be started using mainthread.Run.
 //
 // Note: Some internal window events of SFML depend on calls to PollEvent to
 // take effect. For instance a call to SetTitle will not update the window title
 // until the next call of PollEvent.
-func (win Window) PollEvent() we.Event {
+//
+// Events are recorded while a recording is in progress, and taken from the
+// recording while a replay is in progress; see Record and Replay.
+func (win *Window) PollEvent() (event we.Event) {
+	resized := false
+	mainthread.Call(func() {
+		win.recMu.Lock()
+		defer win.recMu.Unlock()
+		if win.closed {
+			return
+		}
+		if win.play != nil {
+			event, resized = win.replayEvent()
+			return
+		}
+		event = win.pollEvent()
+		_, resized = event.(we.Resize)
+	})
+	if resized {
+		// Rescale once win.recMu is released, as a goroutine rendering to the
+		// window may hold its context while waiting for win.recMu.
+		win.rescale()
+	}
+	if event != nil {
+		win.recMu.Lock()
+		if win.rec != nil {
+			win.rec.record(win.frame, event)
+		}
+		win.recMu.Unlock()
+	}
+	return event
+}
+
+// pollEvent returns a pending event from the event queue or nil if the queue
+// was empty.
+func (win *Window) pollEvent() we.Event {
 	// Poll the event queue until we locate a non-nil event or the queue is
 	// empty.
 	var sfEvent C.sfEvent
@@ -57,21 +122,124 @@ func (win Window) PollEvent() we.Event {
 			// Return nil if the event queue is empty.
 			return nil
 		}
-		if event := weEvent(sfEvent); event != nil {
+		if event := win.weEvent(sfEvent); event != nil {
 			return event
 		}
 	}
 }
 
-// TODO(u): Figure out a better way to handle the previous cursor position. The
-// current way is racy.
+// Focus is triggered when the window gains (true) or loses (false) input
+// focus.
+type Focus bool
+
+// MouseWheel is triggered when the vertical mouse wheel is moved. It is the
+// legacy counterpart of we.ScrollY and is always accompanied by one.
+type MouseWheel struct {
+	// Location of the mouse cursor.
+	image.Point
+	// Number of ticks the wheel has moved; positive is up and negative is down.
+	Delta int
+	// Active keyboard modifiers.
+	Mod we.Mod
+}
+
+// JoystickButtonPress is triggered when a joystick button is pressed.
+type JoystickButtonPress struct {
+	// Index of the joystick.
+	ID int
+	// Index of the pressed button.
+	Button int
+}
+
+// JoystickButtonRelease is triggered when a joystick button is released.
+type JoystickButtonRelease struct {
+	// Index of the joystick.
+	ID int
+	// Index of the released button.
+	Button int
+}
+
+// JoystickMove is triggered when a joystick axis is moved.
+type JoystickMove struct {
+	// Index of the joystick.
+	ID int
+	// Axis which moved.
+	Axis joystick.Axis
+	// New position of the axis, in the range [-100, 100].
+	Pos float64
+}
+
+// JoystickConnect is triggered when a joystick is connected.
+type JoystickConnect struct {
+	// Index of the joystick.
+	ID int
+	// Description of the connected joystick.
+	Device joystick.Device
+}
+
+// JoystickDisconnect is triggered when a joystick is disconnected.
+type JoystickDisconnect struct {
+	// Index of the joystick.
+	ID int
+}
+
+// TouchBegin is triggered when a finger touches the screen.
+type TouchBegin struct {
+	// Location of the touch.
+	image.Point
+	// Index of the finger.
+	Finger int
+}
 
-// prev represents the previously recorded cursor position.
-var prev image.Point
+// TouchMove is triggered when a finger moves while touching the screen.
+type TouchMove struct {
+	// Location of the touch.
+	image.Point
+	// Index of the finger.
+	Finger int
+}
+
+// TouchEnd is triggered when a finger is lifted from the screen.
+type TouchEnd struct {
+	// Location of the touch.
+	image.Point
+	// Index of the finger.
+	Finger int
+}
+
+// Sensor specifies the type of a sensor.
+type Sensor int
+
+// Sensor types.
+const (
+	// SensorAccelerometer measures the raw acceleration (m/s^2).
+	SensorAccelerometer Sensor = C.sfSensorAccelerometer
+	// SensorGyroscope measures the raw rotation rates (degrees/s).
+	SensorGyroscope Sensor = C.sfSensorGyroscope
+	// SensorMagnetometer measures the ambient magnetic field (micro-teslas).
+	SensorMagnetometer Sensor = C.sfSensorMagnetometer
+	// SensorGravity measures the direction and intensity of gravity,
+	// independent of device acceleration (m/s^2).
+	SensorGravity Sensor = C.sfSensorGravity
+	// SensorUserAcceleration measures the direction and intensity of device
+	// acceleration, independent of the gravity (m/s^2).
+	SensorUserAcceleration Sensor = C.sfSensorUserAcceleration
+	// SensorOrientation measures the absolute 3D orientation (degrees).
+	SensorOrientation Sensor = C.sfSensorOrientation
+)
+
+// SensorChange is triggered when the value of a sensor changes.
+type SensorChange struct {
+	// Type of the sensor.
+	Sensor Sensor
+	// Current value of the sensor on the X, Y and Z axes.
+	X, Y, Z float64
+}
 
 // weEvent returns the corresponding we.Event for the provided SFML event or nil
-// if no such event exists.
-func weEvent(sfEvent C.sfEvent) we.Event {
+// if no such event exists. The mouse state of the window is updated based on
+// the event.
+func (win *Window) weEvent(sfEvent C.sfEvent) we.Event {
 	typ := C.getEventType(sfEvent)
 	switch typ {
 	// Window events.
@@ -83,6 +251,12 @@ func weEvent(sfEvent C.sfEvent) we.Event {
 			Width:  int(e.width),
 			Height: int(e.height),
 		}
+	case C.sfEvtLostFocus:
+		// Button releases may be lost while the window is out of focus.
+		win.held = nil
+		return Focus(false)
+	case C.sfEvtGainedFocus:
+		return Focus(true)
 
 	// Keyboard events.
 	case C.sfEvtTextEntered:
@@ -103,8 +277,15 @@ func weEvent(sfEvent C.sfEvent) we.Event {
 
 	// Mouse events.
 	case C.sfEvtMouseWheelMoved:
+		e := C.getMouseWheelEvent(sfEvent)
+		return MouseWheel{
+			Point: win.virtualPt(image.Pt(int(e.x), int(e.y))),
+			Delta: int(e.delta),
+			Mod:   getMod(),
+		}
+	case C.sfEvtMouseWheelScrolled:
 		e := C.getMouseWheelScrollEvent(sfEvent)
-		pt := image.Pt(int(e.x), int(e.y))
+		pt := win.virtualPt(image.Pt(int(e.x), int(e.y)))
 		switch e.wheel {
 		case C.sfMouseHorizontalWheel:
 			return we.ScrollX{
@@ -124,37 +305,134 @@ func weEvent(sfEvent C.sfEvent) we.Event {
 		}
 	case C.sfEvtMouseButtonPressed:
 		e := C.getMouseButtonEvent(sfEvent)
-		pt := image.Pt(int(e.x), int(e.y))
+		pt := win.virtualPt(image.Pt(int(e.x), int(e.y)))
+		button := weButton(e.button)
+		win.press(button)
 		return we.MousePress{
 			Point:  pt,
-			Button: weButton(e.button),
+			Button: button,
 			Mod:    getMod(),
 		}
 	case C.sfEvtMouseButtonReleased:
 		e := C.getMouseButtonEvent(sfEvent)
-		pt := image.Pt(int(e.x), int(e.y))
+		pt := win.virtualPt(image.Pt(int(e.x), int(e.y)))
+		button := weButton(e.button)
+		win.release(button)
 		return we.MouseRelease{
 			Point:  pt,
-			Button: weButton(e.button),
+			Button: button,
 			Mod:    getMod(),
 		}
 	case C.sfEvtMouseMoved:
-		// TODO(u): Implement we.MouseDrag event.
 		e := C.getMouseMoveEvent(sfEvent)
-		pt := image.Pt(int(e.x), int(e.y))
-		event := we.MouseMove{
+		pt := win.virtualPt(image.Pt(int(e.x), int(e.y)))
+		from := win.prev
+		win.prev = pt
+		if len(win.held) > 0 {
+			// The mouse is dragged with the earliest pressed button still held.
+			return we.MouseDrag{
+				Point:  pt,
+				From:   from,
+				Button: win.held[0],
+				Mod:    getMod(),
+			}
+		}
+		return we.MouseMove{
 			Point: pt,
-			From:  prev,
+			From:  from,
 		}
-		prev = pt
-		return event
 	case C.sfEvtMouseEntered:
 		return we.MouseEnter(true)
 	case C.sfEvtMouseLeft:
 		return we.MouseEnter(false)
 
+	// Joystick events.
+	case C.sfEvtJoystickButtonPressed:
+		e := C.getJoystickButtonEvent(sfEvent)
+		return JoystickButtonPress{
+			ID:     int(e.joystickId),
+			Button: int(e.button),
+		}
+	case C.sfEvtJoystickButtonReleased:
+		e := C.getJoystickButtonEvent(sfEvent)
+		return JoystickButtonRelease{
+			ID:     int(e.joystickId),
+			Button: int(e.button),
+		}
+	case C.sfEvtJoystickMoved:
+		e := C.getJoystickMoveEvent(sfEvent)
+		return JoystickMove{
+			ID:   int(e.joystickId),
+			Axis: joystick.Axis(e.axis),
+			Pos:  float64(e.position),
+		}
+	case C.sfEvtJoystickConnected:
+		e := C.getJoystickConnectEvent(sfEvent)
+		id := int(e.joystickId)
+		dev, _ := joystick.Lookup(id)
+		return JoystickConnect{
+			ID:     id,
+			Device: dev,
+		}
+	case C.sfEvtJoystickDisconnected:
+		e := C.getJoystickConnectEvent(sfEvent)
+		return JoystickDisconnect{
+			ID: int(e.joystickId),
+		}
+
+	// Touch events.
+	case C.sfEvtTouchBegan:
+		e := C.getTouchEvent(sfEvent)
+		return TouchBegin{
+			Point:  win.virtualPt(image.Pt(int(e.x), int(e.y))),
+			Finger: int(e.finger),
+		}
+	case C.sfEvtTouchMoved:
+		e := C.getTouchEvent(sfEvent)
+		return TouchMove{
+			Point:  win.virtualPt(image.Pt(int(e.x), int(e.y))),
+			Finger: int(e.finger),
+		}
+	case C.sfEvtTouchEnded:
+		e := C.getTouchEvent(sfEvent)
+		return TouchEnd{
+			Point:  win.virtualPt(image.Pt(int(e.x), int(e.y))),
+			Finger: int(e.finger),
+		}
+
+	// Sensor events.
+	case C.sfEvtSensorChanged:
+		e := C.getSensorEvent(sfEvent)
+		return SensorChange{
+			Sensor: Sensor(e.sensorType),
+			X:      float64(e.x),
+			Y:      float64(e.y),
+			Z:      float64(e.z),
+		}
+
 	default:
 		log.Printf("window.weEvent: support for SFML event type %d not yet implemented", typ)
 		return nil
 	}
 }
+
+// press records that the provided mouse button is held down within the window.
+func (win *Window) press(button we.Button) {
+	for _, b := range win.held {
+		if b == button {
+			return
+		}
+	}
+	win.held = append(win.held, button)
+}
+
+// release records that the provided mouse button is no longer held down within
+// the window.
+func (win *Window) release(button we.Button) {
+	for i, b := range win.held {
+		if b == button {
+			win.held = append(win.held[:i], win.held[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/mewspring/sfml/internal/glctx"
+	"github.com/mewspring/sfml/mainthread"
+)
+
+// windowedState records the state of a window before entering full screen
+// mode.
+type windowedState struct {
+	// Style of the window.
+	style Style
+	// Video mode of the window.
+	mode VideoMode
+	// Position of the window.
+	pos image.Point
+}
+
+// IsFullscreen reports whether the window is in full screen mode, either
+// exclusive or borderless.
+func (win *Window) IsFullscreen() bool {
+	return win.windowed != nil || win.style&FullScreen != 0
+}
+
+// SetFullscreen switches the window between exclusive full screen mode, using
+// the video mode of the desktop, and windowed mode.
+//
+// The native window is recreated internally. The window, its title, icon,
+// cursor and other settings, as well as existing textures and texts, remain
+// valid. The view of the window is reset to its default view.
+//
+// Should the native window fail to be recreated, and the previous native window
+// fail to be restored, an error is returned and the window is closed.
+func (win *Window) SetFullscreen(fullscreen bool) error {
+	if win.isClosed() {
+		return errors.New("Window.SetFullscreen: window closed")
+	}
+	if !fullscreen {
+		return win.leaveFullscreen()
+	}
+	return win.enterFullscreen(FullScreen)
+}
+
+// SetBorderless switches the window between borderless full screen mode, in
+// which a borderless window covers the entire desktop, and windowed mode.
+// Borderless full screen mode allows for fast switching between applications.
+//
+// The native window is recreated internally. The window, its title, icon,
+// cursor and other settings, as well as existing textures and texts, remain
+// valid. The view of the window is reset to its default view.
+//
+// Should the native window fail to be recreated, and the previous native window
+// fail to be restored, an error is returned and the window is closed.
+func (win *Window) SetBorderless(borderless bool) error {
+	if win.isClosed() {
+		return errors.New("Window.SetBorderless: window closed")
+	}
+	if !borderless {
+		return win.leaveFullscreen()
+	}
+	return win.enterFullscreen(Borderless)
+}
+
+// enterFullscreen switches the window to full screen mode, using the provided
+// window style.
+func (win *Window) enterFullscreen(style Style) error {
+	if win.IsFullscreen() && win.style == style {
+		// Already in the requested full screen mode.
+		return nil
+	}
+	switch {
+	case win.windowed != nil:
+		// Switching between full screen modes; keep the windowed state.
+	case win.style&FullScreen != 0:
+		// The window was opened in full screen mode, and has no windowed state
+		// to return to.
+		win.windowed = desktopWindowed(win.mode)
+	default:
+		// Record the current size of the window, which may have changed since
+		// it was opened.
+		size := C.sfRenderWindow_getSize(win.win)
+		mode := win.mode
+		mode.Width, mode.Height = int(size.x), int(size.y)
+		win.windowed = &windowedState{
+			style: win.style,
+			mode:  mode,
+			pos:   win.Pos(),
+		}
+	}
+	if err := win.recreate(DesktopMode(), style); err != nil {
+		return err
+	}
+	if style&FullScreen == 0 {
+		win.SetPos(image.ZP)
+	}
+	return nil
+}
+
+// leaveFullscreen switches the window back to windowed mode.
+func (win *Window) leaveFullscreen() error {
+	prev := win.windowed
+	if prev == nil {
+		if win.style&FullScreen == 0 {
+			// Already in windowed mode.
+			return nil
+		}
+		// The window was opened in full screen mode.
+		prev = desktopWindowed(win.mode)
+	}
+	if err := win.recreate(prev.mode, prev.style); err != nil {
+		return err
+	}
+	win.windowed = nil
+	win.SetPos(prev.pos)
+	return nil
+}
+
+// desktopWindowed returns the windowed state of a window opened in full screen
+// mode with the provided video mode. The window uses the Default style and is
+// centered on the desktop, with its size reduced to fit the desktop.
+func desktopWindowed(mode VideoMode) *windowedState {
+	desktop := DesktopMode()
+	if mode.Width >= desktop.Width || mode.Height >= desktop.Height {
+		// Leave room for the window decorations and the panels of the
+		// desktop.
+		mode.Width = desktop.Width * 3 / 4
+		mode.Height = desktop.Height * 3 / 4
+	}
+	pos := image.Pt((desktop.Width-mode.Width)/2, (desktop.Height-mode.Height)/2)
+	return &windowedState{
+		style: Default,
+		mode:  mode,
+		pos:   pos,
+	}
+}
+
+// recreate recreates the native window using the provided video mode and
+// style, and restores the state of the window. The frame rendered by the calling
+// goroutine, if any, ends.
+func (win *Window) recreate(mode VideoMode, style Style) (err error) {
+	glctx.EndFrame()
+	mainthread.Call(func() {
+		// Wait for other goroutines to finish rendering to the window.
+		win.ctx.Lock()
+		win.ctx.Release()
+		glctx.Lock()
+		defer glctx.Unlock()
+		err = win.recreateWindow(mode, style)
+	})
+	return err
+}
+
+// recreateWindow recreates the native window using the provided video mode and
+// style, and restores the state of the window. Should the previous native window
+// fail to be restored, the window is closed.
+func (win *Window) recreateWindow(mode VideoMode, style Style) error {
+	// Destroy the old native window first, as SFML refuses to open a second
+	// full screen window. The OpenGL contexts of SFML are shared, so textures
+	// survive the destruction of the old native window.
+	C.sfRenderWindow_close(win.win)
+	C.sfRenderWindow_destroy(win.win)
+	var err error
+	w := C.sfRenderWindow_createUnicode(sfmlVideoMode(mode), utf32(win.title), C.sfUint32(style), win.settings)
+	if w == nil {
+		err = fmt.Errorf("Window.recreateWindow: unable to create %dx%d window", mode.Width, mode.Height)
+		// Fall back to the previous video mode and style of the window.
+		mode, style = win.mode, win.style
+		w = C.sfRenderWindow_createUnicode(sfmlVideoMode(mode), utf32(win.title), C.sfUint32(style), win.settings)
+		if w == nil {
+			// The native window is lost; close the window, so that later calls
+			// fail cleanly.
+			win.freeScaler()
+			win.setClosed()
+			return fmt.Errorf("Window.recreateWindow: unable to restore %dx%d window; window closed", mode.Width, mode.Height)
+		}
+	}
+	win.win = w
+	win.style = style
+	win.mode = mode
+	win.held = nil
+	win.restore()
+	// Deactivate the OpenGL context of the window, which is activated on
+	// creation.
+	C.sfRenderWindow_setActive(win.win, C.sfFalse)
+	return err
+}
+
+// restore restores the state of the window after its native window has been
+// recreated.
+func (win *Window) restore() {
+	win.setIcon()
+	if win.cursor != nil {
+		win.SetCursor(win.cursor)
+	}
+	if win.cursorHidden {
+		win.ShowCursor(false)
+	}
+	if win.cursorGrabbed {
+		win.GrabCursor(true)
+	}
+	// Avoid SetVSync and SetFramerateLimit, as the context of the window is
+	// released.
+	if win.vsync {
+		C.sfRenderWindow_setVerticalSyncEnabled(win.win, C.sfTrue)
+	}
+	if win.fpsLimit != 0 {
+		C.sfRenderWindow_setFramerateLimit(win.win, C.uint(win.fpsLimit))
+	}
+	if win.hidden {
+		win.SetVisible(false)
+	}
+	if win.noKeyRepeat {
+		win.SetKeyRepeat(false)
+	}
+	if win.joyThreshold != 0 {
+		win.SetJoystickThreshold(win.joyThreshold)
+	}
+	// Avoid rescale, as the context of the window is released.
+	win.rescaleView()
+}
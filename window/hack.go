@@ -7,7 +7,9 @@ import (
 	"unsafe"
 
 	"github.com/mewspring/sfml/font"
+	"github.com/mewspring/sfml/internal/glctx"
 	"github.com/mewspring/sfml/texture"
+	"github.com/mewspring/sfml/view"
 )
 
 // drawableHack is a copy of texture.Drawable without modifications. Through the
@@ -19,6 +21,8 @@ type drawableHack struct {
 	tex *C.sfRenderTexture
 	// A sprite representation of the GPU texture.
 	sprite *C.sfSprite
+	// OpenGL context of the rendering texture.
+	ctx *glctx.Context
 }
 
 // drawableSprite returns the sprite of the provided texture.Drawable.
@@ -55,3 +59,20 @@ type textHack struct {
 func textText(text *font.Text) *C.sfText {
 	return (*textHack)(unsafe.Pointer(text)).text
 }
+
+// viewHack is a copy of view.View without modifications. Through the use of
+// unsafe and with knowledge of its memory layout we are able to access
+// unexported members. This hack allows us to cross package barriers while
+// keeping the exported API clean.
+type viewHack struct {
+	// A 2D camera.
+	view *C.sfView
+}
+
+// viewView returns the view of the provided view.View, or nil if v is nil.
+func viewView(v *view.View) *C.sfView {
+	if v == nil {
+		return nil
+	}
+	return (*viewHack)(unsafe.Pointer(v)).view
+}
@@ -0,0 +1,57 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"unsafe"
+)
+
+// SetIcon sets the icon of the window. Several sizes of the same icon may be
+// provided, in which case the largest one is used and scaled by the operating
+// system as required.
+func (win *Window) SetIcon(imgs ...image.Image) error {
+	var best image.Image
+	for _, img := range imgs {
+		if best == nil || area(img.Bounds()) > area(best.Bounds()) {
+			best = img
+		}
+	}
+	if best == nil || best.Bounds().Empty() {
+		return errors.New("Window.SetIcon: no icon image provided")
+	}
+	win.icon = nrgba(best)
+	win.setIcon()
+	return nil
+}
+
+// setIcon sets the icon of the native window to the icon of the window.
+func (win *Window) setIcon() {
+	if win.icon == nil {
+		return
+	}
+	width, height := win.icon.Rect.Dx(), win.icon.Rect.Dy()
+	pix := (*C.sfUint8)(unsafe.Pointer(&win.icon.Pix[0]))
+	C.sfRenderWindow_setIcon(win.win, C.uint(width), C.uint(height), pix)
+}
+
+// area returns the area of the provided rectangle.
+func area(r image.Rectangle) int {
+	return r.Dx() * r.Dy()
+}
+
+// nrgba returns the provided image as a non-premultiplied RGBA image with
+// contiguous pixels, converting it if required.
+func nrgba(src image.Image) *image.NRGBA {
+	if img, ok := src.(*image.NRGBA); ok && img.Stride == 4*img.Rect.Dx() {
+		return img
+	}
+	bounds := src.Bounds()
+	dr := image.Rect(0, 0, bounds.Dx(), bounds.Dy())
+	dst := image.NewNRGBA(dr)
+	draw.Draw(dst, dr, src, bounds.Min, draw.Src)
+	return dst
+}
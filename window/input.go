@@ -0,0 +1,254 @@
+package window
+
+import (
+	"image"
+
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/we"
+)
+
+// An Input tracks the state of the keyboard, mouse and joysticks, as reported
+// by the events of a window. Events are passed to Update as they are polled,
+// and Advance is called once per frame, after the state has been queried.
+//
+// A typical frame looks as follows.
+//
+//	for e := win.PollEvent(); e != nil; e = win.PollEvent() {
+//		in.Update(e)
+//	}
+//	if in.JustPressed(we.KeySpace) {
+//		jump()
+//	}
+//	in.Advance()
+type Input struct {
+	// State of the keys, mouse buttons and joystick buttons; keyed by we.Key,
+	// we.Button and joyButton respectively.
+	buttons buttonState
+	// Positions of the joystick axes.
+	axes map[joyAxis]float64
+	// Active keyboard modifiers.
+	mod we.Mod
+	// Position of the mouse cursor.
+	cursor image.Point
+	// Accumulated scroll offset of the current frame.
+	scroll image.Point
+}
+
+// joyButton identifies a button of a joystick.
+type joyButton struct {
+	// Index of the joystick.
+	id int
+	// Index of the button.
+	button int
+}
+
+// joyAxis identifies an axis of a joystick.
+type joyAxis struct {
+	// Index of the joystick.
+	id int
+	// Axis of the joystick.
+	axis joystick.Axis
+}
+
+// NewInput returns a new input state tracker, with no keys or buttons pressed.
+func NewInput() *Input {
+	return &Input{
+		buttons: newButtonState(),
+		axes:    make(map[joyAxis]float64),
+	}
+}
+
+// Update updates the input state based on the provided event.
+func (in *Input) Update(event we.Event) {
+	switch e := event.(type) {
+	case Focus:
+		if !e {
+			// Releases may be lost while the window is out of focus.
+			in.buttons.releaseAll()
+			in.mod = 0
+		}
+
+	// Keyboard events.
+	case we.KeyPress:
+		in.buttons.press(e.Key)
+		in.mod = e.Mod
+	case we.KeyRelease:
+		in.buttons.release(e.Key)
+		in.mod = e.Mod
+
+	// Mouse events.
+	case we.MousePress:
+		in.buttons.press(e.Button)
+		in.cursor, in.mod = e.Point, e.Mod
+	case we.MouseRelease:
+		in.buttons.release(e.Button)
+		in.cursor, in.mod = e.Point, e.Mod
+	case we.MouseMove:
+		in.cursor = e.Point
+	case we.MouseDrag:
+		in.cursor, in.mod = e.Point, e.Mod
+	case we.ScrollX:
+		in.scroll.X += e.Off
+		in.cursor, in.mod = e.Point, e.Mod
+	case we.ScrollY:
+		in.scroll.Y += e.Off
+		in.cursor, in.mod = e.Point, e.Mod
+
+	// Joystick events.
+	case JoystickButtonPress:
+		in.buttons.press(joyButton{id: e.ID, button: e.Button})
+	case JoystickButtonRelease:
+		in.buttons.release(joyButton{id: e.ID, button: e.Button})
+	case JoystickMove:
+		in.axes[joyAxis{id: e.ID, axis: e.Axis}] = e.Pos
+	case JoystickDisconnect:
+		for b := range in.buttons.down {
+			if b, ok := b.(joyButton); ok && b.id == e.ID {
+				in.buttons.release(b)
+			}
+		}
+		for axis := range in.axes {
+			if axis.id == e.ID {
+				delete(in.axes, axis)
+			}
+		}
+	}
+}
+
+// Advance advances the input state to the next frame, which resets the just
+// pressed and just released state of all keys and buttons, and the accumulated
+// scroll offset.
+func (in *Input) Advance() {
+	in.buttons.advance()
+	in.scroll = image.ZP
+}
+
+// Down reports whether the given key is held down.
+func (in *Input) Down(key we.Key) bool {
+	return in.buttons.down[key]
+}
+
+// JustPressed reports whether the given key was pressed during the current
+// frame. Key repeats are not reported.
+func (in *Input) JustPressed(key we.Key) bool {
+	return in.buttons.pressed[key]
+}
+
+// JustReleased reports whether the given key was released during the current
+// frame.
+func (in *Input) JustReleased(key we.Key) bool {
+	return in.buttons.released[key]
+}
+
+// Mod returns the active keyboard modifiers.
+func (in *Input) Mod() we.Mod {
+	return in.mod
+}
+
+// ButtonDown reports whether the given mouse button is held down.
+func (in *Input) ButtonDown(button we.Button) bool {
+	return in.buttons.down[button]
+}
+
+// ButtonJustPressed reports whether the given mouse button was pressed during
+// the current frame.
+func (in *Input) ButtonJustPressed(button we.Button) bool {
+	return in.buttons.pressed[button]
+}
+
+// ButtonJustReleased reports whether the given mouse button was released
+// during the current frame.
+func (in *Input) ButtonJustReleased(button we.Button) bool {
+	return in.buttons.released[button]
+}
+
+// CursorPos returns the position of the mouse cursor, as reported by the last
+// mouse event.
+func (in *Input) CursorPos() image.Point {
+	return in.cursor
+}
+
+// Scroll returns the accumulated horizontal and vertical scroll offsets of the
+// current frame.
+func (in *Input) Scroll() image.Point {
+	return in.scroll
+}
+
+// JoystickDown reports whether the given button of the specified joystick is
+// held down.
+func (in *Input) JoystickDown(id, button int) bool {
+	return in.buttons.down[joyButton{id: id, button: button}]
+}
+
+// JoystickJustPressed reports whether the given button of the specified
+// joystick was pressed during the current frame.
+func (in *Input) JoystickJustPressed(id, button int) bool {
+	return in.buttons.pressed[joyButton{id: id, button: button}]
+}
+
+// JoystickJustReleased reports whether the given button of the specified
+// joystick was released during the current frame.
+func (in *Input) JoystickJustReleased(id, button int) bool {
+	return in.buttons.released[joyButton{id: id, button: button}]
+}
+
+// JoystickPos returns the position, in the range [-100, 100], of the given axis
+// of the specified joystick, as reported by the last joystick move event.
+func (in *Input) JoystickPos(id int, axis joystick.Axis) float64 {
+	return in.axes[joyAxis{id: id, axis: axis}]
+}
+
+// buttonState tracks the state of a set of buttons.
+type buttonState struct {
+	// Buttons held down.
+	down map[interface{}]bool
+	// Buttons pressed during the current frame.
+	pressed map[interface{}]bool
+	// Buttons released during the current frame.
+	released map[interface{}]bool
+}
+
+// newButtonState returns a new button state, with no buttons pressed.
+func newButtonState() buttonState {
+	return buttonState{
+		down:     make(map[interface{}]bool),
+		pressed:  make(map[interface{}]bool),
+		released: make(map[interface{}]bool),
+	}
+}
+
+// press records the press of the given button.
+func (s buttonState) press(b interface{}) {
+	if s.down[b] {
+		// Skip repeated presses.
+		return
+	}
+	s.down[b] = true
+	s.pressed[b] = true
+}
+
+// release records the release of the given button.
+func (s buttonState) release(b interface{}) {
+	if !s.down[b] {
+		return
+	}
+	delete(s.down, b)
+	s.released[b] = true
+}
+
+// releaseAll records the release of all buttons held down.
+func (s buttonState) releaseAll() {
+	for b := range s.down {
+		s.release(b)
+	}
+}
+
+// advance resets the pressed and released buttons of the current frame.
+func (s buttonState) advance() {
+	for b := range s.pressed {
+		delete(s.pressed, b)
+	}
+	for b := range s.released {
+		delete(s.released, b)
+	}
+}
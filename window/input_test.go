@@ -0,0 +1,76 @@
+package window
+
+import (
+	"testing"
+
+	"github.com/mewspring/we"
+)
+
+func TestInput(t *testing.T) {
+	press := we.KeyPress{Key: we.KeySpace}
+	release := we.KeyRelease{Key: we.KeySpace}
+	golden := []struct {
+		// Events of each frame; Advance is called between frames.
+		frames [][]we.Event
+		// Expected state of the space key in the last frame.
+		down, pressed, released bool
+	}{
+		// Press and release within one frame.
+		{
+			frames:   [][]we.Event{{press, release}},
+			pressed:  true,
+			released: true,
+		},
+		// Key held across Advance.
+		{
+			frames: [][]we.Event{{press}, {}},
+			down:   true,
+		},
+		// Key repeat while held.
+		{
+			frames: [][]we.Event{{press}, {press}},
+			down:   true,
+		},
+		// Release after Advance.
+		{
+			frames:   [][]we.Event{{press}, {release}},
+			released: true,
+		},
+		// Release after Advance, followed by a press within the same frame.
+		{
+			frames:   [][]we.Event{{press}, {release, press}},
+			down:     true,
+			pressed:  true,
+			released: true,
+		},
+		// Loss of focus while held.
+		{
+			frames:   [][]we.Event{{press}, {Focus(false)}},
+			released: true,
+		},
+		// State reset by Advance.
+		{
+			frames: [][]we.Event{{press, release}, {}},
+		},
+	}
+	for i, g := range golden {
+		in := NewInput()
+		for j, events := range g.frames {
+			if j > 0 {
+				in.Advance()
+			}
+			for _, e := range events {
+				in.Update(e)
+			}
+		}
+		if got := in.Down(we.KeySpace); got != g.down {
+			t.Errorf("i=%d: Down mismatch; expected %v, got %v", i, g.down, got)
+		}
+		if got := in.JustPressed(we.KeySpace); got != g.pressed {
+			t.Errorf("i=%d: JustPressed mismatch; expected %v, got %v", i, g.pressed, got)
+		}
+		if got := in.JustReleased(we.KeySpace); got != g.released {
+			t.Errorf("i=%d: JustReleased mismatch; expected %v, got %v", i, g.released, got)
+		}
+	}
+}
@@ -51,212 +51,249 @@ func weMod(e C.sfKeyEvent) we.Mod {
 
 // weKey returns the we.Key corresponding to the provided SFML key code.
 func weKey(code C.sfKeyCode) we.Key {
+	if key, ok := lookupKey(code); ok {
+		return key
+	}
+	// Unknown key code.
+	log.Printf("window.weKey: unknown key code %d", code)
+	return 0
+}
+
+// lookupKey returns the we.Key corresponding to the provided SFML key code. The
+// boolean return value indicates success.
+func lookupKey(code C.sfKeyCode) (we.Key, bool) {
 	switch code {
 	case C.sfKeyA:
-		return we.KeyA
+		return we.KeyA, true
 	case C.sfKeyB:
-		return we.KeyB
+		return we.KeyB, true
 	case C.sfKeyC:
-		return we.KeyC
+		return we.KeyC, true
 	case C.sfKeyD:
-		return we.KeyD
+		return we.KeyD, true
 	case C.sfKeyE:
-		return we.KeyE
+		return we.KeyE, true
 	case C.sfKeyF:
-		return we.KeyF
+		return we.KeyF, true
 	case C.sfKeyG:
-		return we.KeyG
+		return we.KeyG, true
 	case C.sfKeyH:
-		return we.KeyH
+		return we.KeyH, true
 	case C.sfKeyI:
-		return we.KeyI
+		return we.KeyI, true
 	case C.sfKeyJ:
-		return we.KeyJ
+		return we.KeyJ, true
 	case C.sfKeyK:
-		return we.KeyK
+		return we.KeyK, true
 	case C.sfKeyL:
-		return we.KeyL
+		return we.KeyL, true
 	case C.sfKeyM:
-		return we.KeyM
+		return we.KeyM, true
 	case C.sfKeyN:
-		return we.KeyN
+		return we.KeyN, true
 	case C.sfKeyO:
-		return we.KeyO
+		return we.KeyO, true
 	case C.sfKeyP:
-		return we.KeyP
+		return we.KeyP, true
 	case C.sfKeyQ:
-		return we.KeyQ
+		return we.KeyQ, true
 	case C.sfKeyR:
-		return we.KeyR
+		return we.KeyR, true
 	case C.sfKeyS:
-		return we.KeyS
+		return we.KeyS, true
 	case C.sfKeyT:
-		return we.KeyT
+		return we.KeyT, true
 	case C.sfKeyU:
-		return we.KeyU
+		return we.KeyU, true
 	case C.sfKeyV:
-		return we.KeyV
+		return we.KeyV, true
 	case C.sfKeyW:
-		return we.KeyW
+		return we.KeyW, true
 	case C.sfKeyX:
-		return we.KeyX
+		return we.KeyX, true
 	case C.sfKeyY:
-		return we.KeyY
+		return we.KeyY, true
 	case C.sfKeyZ:
-		return we.KeyZ
+		return we.KeyZ, true
 	case C.sfKeyNum0:
-		return we.Key0
+		return we.Key0, true
 	case C.sfKeyNum1:
-		return we.Key1
+		return we.Key1, true
 	case C.sfKeyNum2:
-		return we.Key2
+		return we.Key2, true
 	case C.sfKeyNum3:
-		return we.Key3
+		return we.Key3, true
 	case C.sfKeyNum4:
-		return we.Key4
+		return we.Key4, true
 	case C.sfKeyNum5:
-		return we.Key5
+		return we.Key5, true
 	case C.sfKeyNum6:
-		return we.Key6
+		return we.Key6, true
 	case C.sfKeyNum7:
-		return we.Key7
+		return we.Key7, true
 	case C.sfKeyNum8:
-		return we.Key8
+		return we.Key8, true
 	case C.sfKeyNum9:
-		return we.Key9
+		return we.Key9, true
 	case C.sfKeyEscape:
-		return we.KeyEscape
+		return we.KeyEscape, true
 	case C.sfKeyLControl:
-		return we.KeyLeftControl
+		return we.KeyLeftControl, true
 	case C.sfKeyLShift:
-		return we.KeyLeftShift
+		return we.KeyLeftShift, true
 	case C.sfKeyLAlt:
-		return we.KeyLeftAlt
+		return we.KeyLeftAlt, true
 	case C.sfKeyLSystem:
-		return we.KeyLeftSuper
+		return we.KeyLeftSuper, true
 	case C.sfKeyRControl:
-		return we.KeyRightControl
+		return we.KeyRightControl, true
 	case C.sfKeyRShift:
-		return we.KeyRightShift
+		return we.KeyRightShift, true
 	case C.sfKeyRAlt:
-		return we.KeyRightAlt
+		return we.KeyRightAlt, true
 	case C.sfKeyRSystem:
-		return we.KeyRightSuper
+		return we.KeyRightSuper, true
 	case C.sfKeyMenu:
-		return we.KeyMenu
+		return we.KeyMenu, true
 	case C.sfKeyLBracket:
-		return we.KeyLeftBracket
+		return we.KeyLeftBracket, true
 	case C.sfKeyRBracket:
-		return we.KeyRightBracket
+		return we.KeyRightBracket, true
 	case C.sfKeySemiColon:
-		return we.KeySemicolon
+		return we.KeySemicolon, true
 	case C.sfKeyComma:
-		return we.KeyComma
+		return we.KeyComma, true
 	case C.sfKeyPeriod:
-		return we.KeyPeriod
+		return we.KeyPeriod, true
 	//case C.sfKeyQuote:
-	//	return we.KeyXXX
+	//	return we.KeyXXX, true
 	case C.sfKeySlash:
-		return we.KeySlash
+		return we.KeySlash, true
 	case C.sfKeyBackSlash:
-		return we.KeyBackslash
+		return we.KeyBackslash, true
 	//case C.sfKeyTilde:
-	//	return we.KeyXXX
+	//	return we.KeyXXX, true
 	case C.sfKeyEqual:
-		return we.KeyEqual
+		return we.KeyEqual, true
 	case C.sfKeyDash:
-		return we.KeyMinus
+		return we.KeyMinus, true
 	case C.sfKeySpace:
-		return we.KeySpace
+		return we.KeySpace, true
 	case C.sfKeyReturn:
-		return we.KeyEnter
+		return we.KeyEnter, true
 	case C.sfKeyBack:
-		return we.KeyBackspace
+		return we.KeyBackspace, true
 	case C.sfKeyTab:
-		return we.KeyTab
+		return we.KeyTab, true
 	case C.sfKeyPageUp:
-		return we.KeyPageUp
+		return we.KeyPageUp, true
 	case C.sfKeyPageDown:
-		return we.KeyPageDown
+		return we.KeyPageDown, true
 	case C.sfKeyEnd:
-		return we.KeyEnd
+		return we.KeyEnd, true
 	case C.sfKeyHome:
-		return we.KeyHome
+		return we.KeyHome, true
 	case C.sfKeyInsert:
-		return we.KeyInsert
+		return we.KeyInsert, true
 	case C.sfKeyDelete:
-		return we.KeyDelete
+		return we.KeyDelete, true
 	case C.sfKeyAdd:
-		return we.KeyKpAdd
+		return we.KeyKpAdd, true
 	case C.sfKeySubtract:
-		return we.KeyKpSubtract
+		return we.KeyKpSubtract, true
 	case C.sfKeyMultiply:
-		return we.KeyKpMultiply
+		return we.KeyKpMultiply, true
 	case C.sfKeyDivide:
-		return we.KeyKpDivide
+		return we.KeyKpDivide, true
 	case C.sfKeyLeft:
-		return we.KeyLeft
+		return we.KeyLeft, true
 	case C.sfKeyRight:
-		return we.KeyRight
+		return we.KeyRight, true
 	case C.sfKeyUp:
-		return we.KeyUp
+		return we.KeyUp, true
 	case C.sfKeyDown:
-		return we.KeyDown
+		return we.KeyDown, true
 	case C.sfKeyNumpad0:
-		return we.KeyKp0
+		return we.KeyKp0, true
 	case C.sfKeyNumpad1:
-		return we.KeyKp1
+		return we.KeyKp1, true
 	case C.sfKeyNumpad2:
-		return we.KeyKp2
+		return we.KeyKp2, true
 	case C.sfKeyNumpad3:
-		return we.KeyKp3
+		return we.KeyKp3, true
 	case C.sfKeyNumpad4:
-		return we.KeyKp4
+		return we.KeyKp4, true
 	case C.sfKeyNumpad5:
-		return we.KeyKp5
+		return we.KeyKp5, true
 	case C.sfKeyNumpad6:
-		return we.KeyKp6
+		return we.KeyKp6, true
 	case C.sfKeyNumpad7:
-		return we.KeyKp7
+		return we.KeyKp7, true
 	case C.sfKeyNumpad8:
-		return we.KeyKp8
+		return we.KeyKp8, true
 	case C.sfKeyNumpad9:
-		return we.KeyKp9
+		return we.KeyKp9, true
 	case C.sfKeyF1:
-		return we.KeyF1
+		return we.KeyF1, true
 	case C.sfKeyF2:
-		return we.KeyF2
+		return we.KeyF2, true
 	case C.sfKeyF3:
-		return we.KeyF3
+		return we.KeyF3, true
 	case C.sfKeyF4:
-		return we.KeyF4
+		return we.KeyF4, true
 	case C.sfKeyF5:
-		return we.KeyF5
+		return we.KeyF5, true
 	case C.sfKeyF6:
-		return we.KeyF6
+		return we.KeyF6, true
 	case C.sfKeyF7:
-		return we.KeyF7
+		return we.KeyF7, true
 	case C.sfKeyF8:
-		return we.KeyF8
+		return we.KeyF8, true
 	case C.sfKeyF9:
-		return we.KeyF9
+		return we.KeyF9, true
 	case C.sfKeyF10:
-		return we.KeyF10
+		return we.KeyF10, true
 	case C.sfKeyF11:
-		return we.KeyF11
+		return we.KeyF11, true
 	case C.sfKeyF12:
-		return we.KeyF12
+		return we.KeyF12, true
 	case C.sfKeyF13:
-		return we.KeyF13
+		return we.KeyF13, true
 	case C.sfKeyF14:
-		return we.KeyF14
+		return we.KeyF14, true
 	case C.sfKeyF15:
-		return we.KeyF15
+		return we.KeyF15, true
 	case C.sfKeyPause:
-		return we.KeyPause
+		return we.KeyPause, true
 	}
+	return 0, false
+}
 
-	// Unknown key code.
-	log.Printf("window.weKey: unknown key code %d", code)
-	return 0
+// sfmlKeys maps from we.Key to the corresponding SFML key codes.
+var sfmlKeys = make(map[we.Key]C.sfKeyCode)
+
+func init() {
+	for code := C.sfKeyCode(0); code < C.sfKeyCount; code++ {
+		if key, ok := lookupKey(code); ok {
+			sfmlKeys[key] = code
+		}
+	}
+}
+
+// KeyPressed reports whether the given key is currently pressed. Unlike key
+// events, the state is queried directly from the keyboard, regardless of which
+// window has focus. During a replay, the key state of the replayed events is
+// reported instead.
+func (win *Window) KeyPressed(key we.Key) bool {
+	win.recMu.Lock()
+	if play := win.play; play != nil {
+		defer win.recMu.Unlock()
+		return play.keys[key]
+	}
+	win.recMu.Unlock()
+	code, ok := sfmlKeys[key]
+	if !ok {
+		return false
+	}
+	return C.sfKeyboard_isKeyPressed(code) == C.sfTrue
 }
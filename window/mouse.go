@@ -29,3 +29,39 @@ func weButton(button C.sfMouseButton) we.Button {
 	log.Printf("window.weButton: unknown mouse button %d", button)
 	return 0
 }
+
+// sfmlButton returns the SFML mouse button corresponding to the provided
+// we.Button. The boolean return value indicates success.
+func sfmlButton(button we.Button) (C.sfMouseButton, bool) {
+	switch button {
+	case we.ButtonLeft:
+		return C.sfMouseLeft, true
+	case we.ButtonRight:
+		return C.sfMouseRight, true
+	case we.ButtonMiddle:
+		return C.sfMouseMiddle, true
+	case we.Button4:
+		return C.sfMouseXButton1, true
+	case we.Button5:
+		return C.sfMouseXButton2, true
+	}
+	return 0, false
+}
+
+// ButtonPressed reports whether the given mouse button is currently pressed.
+// Unlike mouse events, the state is queried directly from the mouse, regardless
+// of which window has focus. During a replay, the button state of the replayed
+// events is reported instead.
+func (win *Window) ButtonPressed(button we.Button) bool {
+	win.recMu.Lock()
+	if play := win.play; play != nil {
+		defer win.recMu.Unlock()
+		return play.buttons[button]
+	}
+	win.recMu.Unlock()
+	b, ok := sfmlButton(button)
+	if !ok {
+		return false
+	}
+	return C.sfMouse_isButtonPressed(b) == C.sfTrue
+}
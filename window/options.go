@@ -0,0 +1,67 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"image"
+)
+
+// An Option customizes the creation of a window; see Open.
+type Option func(conf *config)
+
+// config holds the customizations of a window being created.
+type config struct {
+	// Title of the window.
+	title string
+	// Position of the window; or nil if decided by the window manager.
+	pos *image.Point
+	// Style of the window.
+	style Style
+	// Video mode of the window; or nil if based on the dimensions passed to
+	// Open.
+	mode *VideoMode
+	// Requested context settings of the window; or nil if the driver defaults
+	// are used.
+	settings *C.sfContextSettings
+}
+
+// WithTitle sets the initial title of the window.
+func WithTitle(title string) Option {
+	return func(conf *config) {
+		conf.title = title
+	}
+}
+
+// WithPos sets the initial position of the window on the desktop.
+func WithPos(pos image.Point) Option {
+	return func(conf *config) {
+		conf.pos = &pos
+	}
+}
+
+// WithStyle sets the style of the window; e.g. Titlebar|Closable, Borderless or
+// FullScreen.
+func WithStyle(style Style) Option {
+	return func(conf *config) {
+		conf.style = style
+	}
+}
+
+// WithVideoMode sets the video mode of the window, which overrides the
+// dimensions passed to Open and sets the pixel depth of the window.
+func WithVideoMode(mode VideoMode) Option {
+	return func(conf *config) {
+		conf.mode = &mode
+	}
+}
+
+// WithContextSettings requests the provided OpenGL context settings for the
+// window; e.g. the antialiasing level. The driver may grant different settings;
+// use the Settings method of the window to query them.
+func WithContextSettings(settings ContextSettings) Option {
+	return func(conf *config) {
+		sfSettings := sfmlContextSettings(settings)
+		conf.settings = &sfSettings
+	}
+}
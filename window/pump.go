@@ -0,0 +1,334 @@
+package window
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mewspring/sfml/mainthread"
+	"github.com/mewspring/we"
+)
+
+// A WindowEvent is an event together with the window it originates from.
+type WindowEvent struct {
+	// Window of the event.
+	Win *Window
+	// Event of the window.
+	Event we.Event
+}
+
+// A Pump multiplexes the events of several windows onto a channel. Events are
+// polled on the main thread, and may be received from any goroutine.
+//
+// Every event is passed to the registered handler of its event type and to the
+// OnEvent handler, if any, and subsequently sent on the Events channel if Events
+// has been called. Handlers are invoked from the goroutine which polls the
+// pump, not from the main thread.
+type Pump struct {
+	// Held while the events of the windows are polled.
+	pollMu sync.Mutex
+	// Tracks the events being sent on the event channel.
+	sending sync.WaitGroup
+	// Protects the fields below.
+	mu sync.Mutex
+	// Windows of the pump.
+	wins []*Window
+	// Event channel; or nil if Events has not been called.
+	events chan WindowEvent
+	// Closed by Stop to abort the events being sent on the event channel; or
+	// nil if Events has not been called.
+	done chan struct{}
+	// Specifies whether the event channel is closed.
+	closed bool
+	// Closed to stop the polling goroutine of the pump; or nil if not started.
+	stop chan struct{}
+	// Event handlers of the pump.
+	handlers handlers
+}
+
+// NewPump returns a new event pump for the provided windows.
+func NewPump(wins ...*Window) *Pump {
+	return &Pump{
+		wins: wins,
+	}
+}
+
+// Add adds the provided window to the pump.
+func (p *Pump) Add(win *Window) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.wins = append(p.wins, win)
+}
+
+// Remove removes the provided window from the pump. It waits for the pending
+// events of the windows to be polled, if in progress, so that the window is no
+// longer used by the pump once Remove returns.
+//
+// Note: A window must be removed from the pump before it is closed.
+func (p *Pump) Remove(win *Window) {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, w := range p.wins {
+		if w == win {
+			p.wins = append(p.wins[:i], p.wins[i+1:]...)
+			return
+		}
+	}
+}
+
+// Events returns the channel on which the events of the windows are sent. The
+// channel is closed by Stop.
+//
+// Note: Once Events has been called, the events must be received from the
+// channel, or the pump blocks when its buffer is full.
+func (p *Pump) Events() <-chan WindowEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.events == nil {
+		p.events = make(chan WindowEvent, 256)
+		p.done = make(chan struct{})
+	}
+	return p.events
+}
+
+// Poll polls the pending events of the windows on the main thread and
+// dispatches them.
+func (p *Pump) Poll() {
+	for _, event := range p.poll() {
+		p.dispatch(event)
+	}
+}
+
+// poll polls the pending events of the windows on the main thread.
+func (p *Pump) poll() []WindowEvent {
+	p.pollMu.Lock()
+	defer p.pollMu.Unlock()
+	p.mu.Lock()
+	wins := append([]*Window(nil), p.wins...)
+	p.mu.Unlock()
+	var events []WindowEvent
+	mainthread.Call(func() {
+		for _, win := range wins {
+			for e := win.PollEvent(); e != nil; e = win.PollEvent() {
+				events = append(events, WindowEvent{Win: win, Event: e})
+			}
+		}
+	})
+	return events
+}
+
+// Start starts polling the events of the windows at the provided interval, from
+// a separate goroutine. Handlers are invoked from that goroutine.
+//
+// Note: The events are polled on the main thread, as required by some operating
+// systems, which requires the program to be started using mainthread.Run. While
+// Run is not active, the events are polled on the thread of the goroutine.
+func (p *Pump) Start(interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != nil {
+		// Already started.
+		return
+	}
+	stop := make(chan struct{})
+	p.stop = stop
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.Poll()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the events of the windows, as started by Start, and closes
+// the Events channel. Events being sent on the channel are dropped, and no
+// events are sent on the channel once Stop returns.
+func (p *Pump) Stop() {
+	p.mu.Lock()
+	if p.stop != nil {
+		close(p.stop)
+		p.stop = nil
+	}
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	if p.events == nil {
+		p.events = make(chan WindowEvent, 256)
+		p.done = make(chan struct{})
+	}
+	close(p.done)
+	p.mu.Unlock()
+	// Wait for the aborted sends before closing the event channel.
+	p.sending.Wait()
+	close(p.events)
+}
+
+// handlers holds the event handlers of a pump.
+type handlers struct {
+	onEvent        func(win *Window, event we.Event)
+	onClose        func(win *Window, e we.Close)
+	onResize       func(win *Window, e we.Resize)
+	onFocus        func(win *Window, e Focus)
+	onKeyPress     func(win *Window, e we.KeyPress)
+	onKeyRelease   func(win *Window, e we.KeyRelease)
+	onKeyRune      func(win *Window, e we.KeyRune)
+	onMousePress   func(win *Window, e we.MousePress)
+	onMouseRelease func(win *Window, e we.MouseRelease)
+	onMouseMove    func(win *Window, e we.MouseMove)
+	onMouseDrag    func(win *Window, e we.MouseDrag)
+}
+
+// OnEvent sets the handler of all events. It is invoked after the handler of
+// the specific event type, if any. A nil handler removes the current handler.
+func (p *Pump) OnEvent(f func(win *Window, event we.Event)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onEvent = f
+}
+
+// OnClose sets the handler of close events.
+func (p *Pump) OnClose(f func(win *Window, e we.Close)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onClose = f
+}
+
+// OnResize sets the handler of resize events.
+func (p *Pump) OnResize(f func(win *Window, e we.Resize)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onResize = f
+}
+
+// OnFocus sets the handler of focus events.
+func (p *Pump) OnFocus(f func(win *Window, e Focus)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onFocus = f
+}
+
+// OnKeyPress sets the handler of key press events.
+func (p *Pump) OnKeyPress(f func(win *Window, e we.KeyPress)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onKeyPress = f
+}
+
+// OnKeyRelease sets the handler of key release events.
+func (p *Pump) OnKeyRelease(f func(win *Window, e we.KeyRelease)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onKeyRelease = f
+}
+
+// OnKeyRune sets the handler of key rune events.
+func (p *Pump) OnKeyRune(f func(win *Window, e we.KeyRune)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onKeyRune = f
+}
+
+// OnMousePress sets the handler of mouse press events.
+func (p *Pump) OnMousePress(f func(win *Window, e we.MousePress)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onMousePress = f
+}
+
+// OnMouseRelease sets the handler of mouse release events.
+func (p *Pump) OnMouseRelease(f func(win *Window, e we.MouseRelease)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onMouseRelease = f
+}
+
+// OnMouseMove sets the handler of mouse move events.
+func (p *Pump) OnMouseMove(f func(win *Window, e we.MouseMove)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onMouseMove = f
+}
+
+// OnMouseDrag sets the handler of mouse drag events.
+func (p *Pump) OnMouseDrag(f func(win *Window, e we.MouseDrag)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers.onMouseDrag = f
+}
+
+// dispatch passes the provided event to its handlers and the event channel.
+func (p *Pump) dispatch(event WindowEvent) {
+	p.mu.Lock()
+	h, events, done := p.handlers, p.events, p.done
+	p.mu.Unlock()
+	win := event.Win
+	switch e := event.Event.(type) {
+	case we.Close:
+		if h.onClose != nil {
+			h.onClose(win, e)
+		}
+	case we.Resize:
+		if h.onResize != nil {
+			h.onResize(win, e)
+		}
+	case Focus:
+		if h.onFocus != nil {
+			h.onFocus(win, e)
+		}
+	case we.KeyPress:
+		if h.onKeyPress != nil {
+			h.onKeyPress(win, e)
+		}
+	case we.KeyRelease:
+		if h.onKeyRelease != nil {
+			h.onKeyRelease(win, e)
+		}
+	case we.KeyRune:
+		if h.onKeyRune != nil {
+			h.onKeyRune(win, e)
+		}
+	case we.MousePress:
+		if h.onMousePress != nil {
+			h.onMousePress(win, e)
+		}
+	case we.MouseRelease:
+		if h.onMouseRelease != nil {
+			h.onMouseRelease(win, e)
+		}
+	case we.MouseMove:
+		if h.onMouseMove != nil {
+			h.onMouseMove(win, e)
+		}
+	case we.MouseDrag:
+		if h.onMouseDrag != nil {
+			h.onMouseDrag(win, e)
+		}
+	}
+	if h.onEvent != nil {
+		h.onEvent(win, event.Event)
+	}
+	if events == nil {
+		return
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.sending.Add(1)
+	p.mu.Unlock()
+	defer p.sending.Done()
+	select {
+	case events <- event:
+	case <-done:
+	}
+}
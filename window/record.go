@@ -0,0 +1,356 @@
+package window
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/mewspring/we"
+)
+
+// Event recordings are stored as lines of JSON. The first line holds a header,
+// which identifies the format and its version, and each subsequent line holds
+// a recorded event; e.g.
+//
+//	{"format":"sfml-events","version":1}
+//	{"frame":0,"time":16000000,"type":"we.MouseMove","event":{"X":10,"Y":20,"From":{"X":0,"Y":0},"Mod":0}}
+//	{"frame":3,"time":52000000,"type":"we.KeyPress","event":{"Key":1,"Mod":0}}
+//
+// The frame of an event is the number of frames displayed since the start of
+// the recording, and its time is the duration in nanoseconds since the start of
+// the recording.
+
+// Identification of the event recording format.
+const (
+	// recordFormat is the name of the event recording format.
+	recordFormat = "sfml-events"
+	// recordVersion is the current version of the event recording format.
+	recordVersion = 1
+)
+
+// recordHeader is the header of an event recording.
+type recordHeader struct {
+	// Name of the event recording format.
+	Format string `json:"format"`
+	// Version of the event recording format.
+	Version int `json:"version"`
+}
+
+// eventRecord is a recorded event.
+type eventRecord struct {
+	// Frame of the event, relative to the start of the recording.
+	Frame uint64 `json:"frame"`
+	// Time of the event, relative to the start of the recording.
+	Time time.Duration `json:"time"`
+	// Type name of the event; e.g. "we.KeyPress".
+	Type string `json:"type"`
+	// Event encoded as JSON.
+	Event json.RawMessage `json:"event"`
+}
+
+// eventTypes maps from type names to the event types which may be recorded.
+var eventTypes = make(map[string]reflect.Type)
+
+func init() {
+	events := []we.Event{
+		we.Close{},
+		we.Resize{},
+		Focus(false),
+		we.KeyRune(0),
+		we.KeyPress{},
+		we.KeyRelease{},
+		MouseWheel{},
+		we.ScrollX{},
+		we.ScrollY{},
+		we.MousePress{},
+		we.MouseRelease{},
+		we.MouseMove{},
+		we.MouseDrag{},
+		we.MouseEnter(false),
+		JoystickButtonPress{},
+		JoystickButtonRelease{},
+		JoystickMove{},
+		JoystickConnect{},
+		JoystickDisconnect{},
+		TouchBegin{},
+		TouchMove{},
+		TouchEnd{},
+		SensorChange{},
+	}
+	for _, event := range events {
+		typ := reflect.TypeOf(event)
+		eventTypes[typ.String()] = typ
+	}
+}
+
+// recorder records the events of a window.
+type recorder struct {
+	// Buffered output of the recording.
+	w *bufio.Writer
+	// JSON encoder of the recording.
+	enc *json.Encoder
+	// Frame of the window at the start of the recording.
+	frame uint64
+	// Time at the start of the recording.
+	start time.Time
+	// First error encountered while recording; or nil.
+	err error
+}
+
+// Record starts recording the events returned by PollEvent to w. The recording
+// includes the frame number and timestamp of every event, and may be replayed
+// using Replay. Any recording in progress is stopped first.
+func (win *Window) Record(w io.Writer) error {
+	if err := win.StopRecording(); err != nil {
+		return fmt.Errorf("Window.Record: %v", err)
+	}
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	rec, err := newRecorder(w, win.frame)
+	if err != nil {
+		return fmt.Errorf("Window.Record: %v", err)
+	}
+	win.rec = rec
+	return nil
+}
+
+// newRecorder returns a new recorder which records events to w, starting at
+// the given frame of the window. The header of the recording is written to w.
+func newRecorder(w io.Writer, frame uint64) (*recorder, error) {
+	bw := bufio.NewWriter(w)
+	rec := &recorder{
+		w:     bw,
+		enc:   json.NewEncoder(bw),
+		frame: frame,
+		start: time.Now(),
+	}
+	header := recordHeader{
+		Format:  recordFormat,
+		Version: recordVersion,
+	}
+	if err := rec.enc.Encode(header); err != nil {
+		return nil, fmt.Errorf("unable to write header; %v", err)
+	}
+	return rec, nil
+}
+
+// StopRecording stops recording the events of the window and flushes the
+// recording. It returns the first error encountered while recording, if any.
+func (win *Window) StopRecording() error {
+	win.recMu.Lock()
+	rec := win.rec
+	win.rec = nil
+	win.recMu.Unlock()
+	if rec == nil {
+		return nil
+	}
+	if rec.err != nil {
+		return fmt.Errorf("Window.StopRecording: %v", rec.err)
+	}
+	if err := rec.w.Flush(); err != nil {
+		return fmt.Errorf("Window.StopRecording: %v", err)
+	}
+	return nil
+}
+
+// record records the provided event at the given frame of the window.
+func (rec *recorder) record(frame uint64, event we.Event) {
+	if rec.err != nil {
+		return
+	}
+	buf, err := json.Marshal(event)
+	if err != nil {
+		rec.err = fmt.Errorf("unable to encode event %#v; %v", event, err)
+		return
+	}
+	r := eventRecord{
+		Frame: frame - rec.frame,
+		Time:  time.Since(rec.start),
+		Type:  reflect.TypeOf(event).String(),
+		Event: buf,
+	}
+	if err := rec.enc.Encode(r); err != nil {
+		rec.err = err
+	}
+}
+
+// replayer replays recorded events to a window.
+type replayer struct {
+	// Recorded events which remain to be replayed.
+	events []recordedEvent
+	// Frame of the window at the start of the replay.
+	frame uint64
+	// Pixel position of the cursor, as specified by the replayed events.
+	cursor image.Point
+	// Keyboard modifiers, as specified by the replayed events.
+	mod we.Mod
+	// Pressed keys, as specified by the replayed events.
+	keys map[we.Key]bool
+	// Pressed mouse buttons, as specified by the replayed events.
+	buttons map[we.Button]bool
+}
+
+// recordedEvent is a decoded event of a recording.
+type recordedEvent struct {
+	// Frame of the event, relative to the start of the recording.
+	frame uint64
+	// Recorded event.
+	event we.Event
+}
+
+// Replay starts replaying the events recorded in r. Every recorded event is
+// returned by PollEvent once the same number of frames has been displayed as
+// when it was recorded, which makes the replay deterministic for programs which
+// advance their state once per frame.
+//
+// While the replay is in progress, real events of the window are discarded,
+// except for close events, and CursorPos, Mod, KeyPressed and ButtonPressed
+// report the state of the replayed events. The replay ends once all recorded events have been returned.
+func (win *Window) Replay(r io.Reader) error {
+	events, err := decodeRecording(r)
+	if err != nil {
+		return fmt.Errorf("Window.Replay: %v", err)
+	}
+	play := &replayer{
+		events:  events,
+		cursor:  win.pixelPt(win.prev),
+		keys:    make(map[we.Key]bool),
+		buttons: make(map[we.Button]bool),
+	}
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	play.frame = win.frame
+	win.play = play
+	return nil
+}
+
+// decodeRecording decodes the events of the recording read from r.
+func decodeRecording(r io.Reader) ([]recordedEvent, error) {
+	dec := json.NewDecoder(r)
+	var header recordHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("unable to read header; %v", err)
+	}
+	if header.Format != recordFormat {
+		return nil, fmt.Errorf("invalid format %q; expected %q", header.Format, recordFormat)
+	}
+	if header.Version < 1 || header.Version > recordVersion {
+		return nil, fmt.Errorf("unsupported version %d; expected %d or below", header.Version, recordVersion)
+	}
+	var events []recordedEvent
+	for {
+		var rec eventRecord
+		if err := dec.Decode(&rec); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("unable to read event; %v", err)
+		}
+		typ, ok := eventTypes[rec.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown event type %q", rec.Type)
+		}
+		v := reflect.New(typ)
+		if err := json.Unmarshal(rec.Event, v.Interface()); err != nil {
+			return nil, fmt.Errorf("unable to decode event %q; %v", rec.Type, err)
+		}
+		e := recordedEvent{
+			frame: rec.Frame,
+			event: v.Elem().Interface(),
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// Replaying reports whether a replay of recorded events is in progress.
+func (win *Window) Replaying() bool {
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	return win.play != nil
+}
+
+// replayEvent returns the next recorded event of the current frame or nil if
+// no such event exists. Real events of the window are discarded, except for
+// close events which are returned as is. The returned resized value reports
+// whether the window has been resized, either for real or by a replayed event,
+// and should be rescaled. The caller must hold win.recMu.
+func (win *Window) replayEvent() (event we.Event, resized bool) {
+	// Discard real events, without affecting the mouse state of the window.
+	held, prev := append([]we.Button(nil), win.held...), win.prev
+	for e := win.pollEvent(); e != nil; e = win.pollEvent() {
+		switch e.(type) {
+		case we.Close:
+			// Let the user close the window during a replay.
+			win.held, win.prev = held, prev
+			return e, resized
+		case we.Resize:
+			resized = true
+		}
+	}
+	win.held, win.prev = held, prev
+	play := win.play
+	if len(play.events) == 0 {
+		// The replay has ended.
+		win.play = nil
+		return nil, resized
+	}
+	next := play.events[0]
+	if next.frame > win.frame-play.frame {
+		return nil, resized
+	}
+	play.events = play.events[1:]
+	switch e := next.event.(type) {
+	case we.Resize:
+		resized = true
+	case we.KeyPress:
+		play.mod = e.Mod
+		play.keys[e.Key] = true
+	case we.KeyRelease:
+		play.mod = e.Mod
+		delete(play.keys, e.Key)
+	case we.MousePress:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+		play.buttons[e.Button] = true
+		win.press(e.Button)
+	case we.MouseRelease:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+		delete(play.buttons, e.Button)
+		win.release(e.Button)
+	case we.MouseMove:
+		play.cursor = win.pixelPt(e.Point)
+		win.prev = e.Point
+	case we.MouseDrag:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+		win.prev = e.Point
+	case we.ScrollX:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+	case we.ScrollY:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+	case MouseWheel:
+		play.cursor, play.mod = win.pixelPt(e.Point), e.Mod
+	case Focus:
+		if !e {
+			play.mod = 0
+			play.keys = make(map[we.Key]bool)
+			play.buttons = make(map[we.Button]bool)
+			win.held = nil
+		}
+	}
+	return next.event, resized
+}
+
+// Mod returns the active keyboard modifiers. During a replay, the modifiers
+// of the replayed events are reported instead.
+func (win *Window) Mod() we.Mod {
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	if win.play != nil {
+		return win.play.mod
+	}
+	return getMod()
+}
@@ -0,0 +1,105 @@
+package window
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mewspring/sfml/joystick"
+	"github.com/mewspring/we"
+)
+
+func TestRecordReplay(t *testing.T) {
+	golden := []recordedEvent{
+		{frame: 0, event: we.Resize{Width: 640, Height: 480}},
+		{frame: 0, event: Focus(true)},
+		{frame: 1, event: we.MouseMove{Point: image.Pt(10, 20), From: image.Pt(0, 0)}},
+		{frame: 1, event: we.MousePress{Point: image.Pt(10, 20), Button: we.ButtonLeft, Mod: we.ModShift}},
+		{frame: 2, event: we.MouseDrag{Point: image.Pt(15, 25), From: image.Pt(10, 20), Button: we.ButtonLeft}},
+		{frame: 2, event: we.MouseRelease{Point: image.Pt(15, 25), Button: we.ButtonLeft}},
+		{frame: 3, event: we.KeyPress{Key: we.KeySpace}},
+		{frame: 3, event: we.KeyRune('x')},
+		{frame: 4, event: we.KeyRelease{Key: we.KeySpace}},
+		{frame: 4, event: we.ScrollY{Point: image.Pt(1, 2), Off: -1}},
+		{frame: 5, event: MouseWheel{Point: image.Pt(1, 2), Delta: -1}},
+		{frame: 5, event: we.MouseEnter(false)},
+		{frame: 6, event: JoystickButtonPress{ID: 1, Button: 3}},
+		{frame: 6, event: JoystickMove{ID: 1, Axis: joystick.Y, Pos: -42.5}},
+		{frame: 7, event: JoystickConnect{ID: 2, Device: joystick.Device{ID: 2, Name: "pad", VendorID: 0x045E, ProductID: 0x028E, Buttons: 11, Axes: []joystick.Axis{joystick.X, joystick.Y}}}},
+		{frame: 7, event: JoystickDisconnect{ID: 2}},
+		{frame: 8, event: TouchBegin{Point: image.Pt(3, 4), Finger: 1}},
+		{frame: 9, event: we.Close{}},
+	}
+	// Record the events, starting at frame 100 of the window.
+	const start = 100
+	buf := &bytes.Buffer{}
+	rec, err := newRecorder(buf, start)
+	if err != nil {
+		t.Fatalf("unable to create recorder; %v", err)
+	}
+	for _, e := range golden {
+		rec.record(start+e.frame, e.event)
+	}
+	if rec.err != nil {
+		t.Fatalf("unable to record events; %v", rec.err)
+	}
+	if err := rec.w.Flush(); err != nil {
+		t.Fatalf("unable to flush recording; %v", err)
+	}
+	// Decode the recording.
+	got, err := decodeRecording(buf)
+	if err != nil {
+		t.Fatalf("unable to decode recording; %v", err)
+	}
+	if len(got) != len(golden) {
+		t.Fatalf("number of events mismatch; expected %d, got %d", len(golden), len(got))
+	}
+	for i, want := range golden {
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("event %d: mismatch; expected %#v, got %#v", i, want, got[i])
+		}
+	}
+}
+
+func TestDecodeRecordingInvalid(t *testing.T) {
+	const header = `{"format":"sfml-events","version":1}` + "\n"
+	golden := []struct {
+		in  string
+		err string
+	}{
+		// Valid recordings.
+		{in: header},
+		{in: header + `{"frame":0,"time":0,"type":"we.Close","event":{}}` + "\n"},
+		// Invalid headers.
+		{in: "", err: "unable to read header"},
+		{in: "{", err: "unable to read header"},
+		{in: `{"format":"foo","version":1}`, err: `invalid format "foo"`},
+		{in: `{"version":1}`, err: `invalid format ""`},
+		// Unsupported versions.
+		{in: `{"format":"sfml-events","version":0}`, err: "unsupported version 0"},
+		{in: fmt.Sprintf(`{"format":"sfml-events","version":%d}`, recordVersion+1), err: fmt.Sprintf("unsupported version %d", recordVersion+1)},
+		// Invalid events.
+		{in: header + "{", err: "unable to read event"},
+		{in: header + `{"frame":0,"time":0,"type":"we.Foo","event":{}}`, err: `unknown event type "we.Foo"`},
+		{in: header + `{"frame":0,"time":0,"type":"we.KeyPress","event":[]}`, err: `unable to decode event "we.KeyPress"`},
+	}
+	for _, g := range golden {
+		_, err := decodeRecording(strings.NewReader(g.in))
+		if g.err == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error; %v", g.in, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%q: expected error containing %q, got nil", g.in, g.err)
+			continue
+		}
+		if !strings.Contains(err.Error(), g.err) {
+			t.Errorf("%q: error mismatch; expected error containing %q, got %q", g.in, g.err, err)
+		}
+	}
+}
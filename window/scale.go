@@ -0,0 +1,184 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Scaling specifies how the contents of a window are scaled to fit the window
+// when it is resized.
+type Scaling int
+
+// Scaling policies.
+const (
+	// Stretch stretches the virtual resolution to fill the entire window,
+	// disregarding its aspect ratio.
+	Stretch Scaling = iota + 1
+	// Letterbox scales the virtual resolution uniformly to fit the window,
+	// preserving its aspect ratio. The remaining area of the window is filled
+	// with black bars.
+	Letterbox
+	// IntegerScale scales the virtual resolution by the largest integer factor
+	// which fits the window, for pixel-perfect rendering. The remaining area of
+	// the window is filled with black bars. Windows smaller than the virtual
+	// resolution fall back to Letterbox.
+	IntegerScale
+	// Expand scales the virtual resolution uniformly to fit the window and
+	// expands the logical area to fill the remaining area of the window. The
+	// top-left corner of the logical area is always at (0, 0).
+	Expand
+)
+
+// scaler keeps track of the scaling policy of a window.
+type scaler struct {
+	// Scaling policy; or 0 if the contents of the window are not scaled.
+	mode Scaling
+	// Virtual resolution of the window.
+	virtual image.Point
+	// View of the window based on the scaling policy and the window size.
+	view *C.sfView
+	// Rectangle used to fill the virtual area of the window.
+	rect *C.sfRectangleShape
+}
+
+// SetScaling sets the scaling policy of the window, which scales the provided
+// virtual resolution to fit the window whenever it is resized. CursorPos and
+// the positions of mouse and touch events are reported in virtual coordinates.
+//
+// A mode of 0 removes the scaling policy of the window, and resets the window
+// to the default SFML view; the virtual resolution is then ignored.
+//
+// Note: SetScaling replaces the default view of the window, see SetView. The
+// window is reset to its default view whenever it is resized.
+func (win *Window) SetScaling(mode Scaling, width, height int) {
+	if mode == 0 {
+		if win.scale.view != nil {
+			C.sfRenderWindow_setView(win.win, C.sfRenderWindow_getDefaultView(win.win))
+			win.freeScaler()
+			win.scale = scaler{}
+		}
+		return
+	}
+	if win.scale.view == nil {
+		win.scale.view = C.sfView_create()
+		win.scale.rect = C.sfRectangleShape_create()
+	}
+	win.scale.mode = mode
+	win.scale.virtual = image.Pt(width, height)
+	C.sfRectangleShape_setSize(win.scale.rect, sfmlFloatPt(win.scale.virtual))
+	win.rescale()
+}
+
+// rescale updates the view of the window based on its scaling policy and
+// current size. It acquires exclusive access to the window, and thus waits for
+// a frame being rendered to the window by another goroutine to end.
+func (win *Window) rescale() {
+	if win.scale.view == nil {
+		return
+	}
+	win.lock()
+	defer win.unlock()
+	win.rescaleView()
+}
+
+// rescaleView updates the view of the window based on its scaling policy and
+// current size. The caller must have exclusive access to the window.
+func (win *Window) rescaleView() {
+	if win.scale.view == nil {
+		return
+	}
+	size := C.sfRenderWindow_getSize(win.win)
+	ww, wh := float64(size.x), float64(size.y)
+	vw, vh := float64(win.scale.virtual.X), float64(win.scale.virtual.Y)
+	if ww == 0 || wh == 0 || vw == 0 || vh == 0 {
+		// Skip minimized windows and empty virtual resolutions.
+		return
+	}
+	// Logical area and viewport, in pixels, of the view.
+	lw, lh := vw, vh
+	vx, vy, vpw, vph := 0.0, 0.0, ww, wh
+	s := math.Min(ww/vw, wh/vh)
+	switch win.scale.mode {
+	case Letterbox:
+		vpw, vph = vw*s, vh*s
+		vx, vy = (ww-vpw)/2, (wh-vph)/2
+	case IntegerScale:
+		if s >= 1 {
+			s = math.Floor(s)
+		}
+		vpw, vph = vw*s, vh*s
+		// Align the viewport to whole pixels.
+		vx, vy = math.Floor((ww-vpw)/2), math.Floor((wh-vph)/2)
+	case Expand:
+		lw, lh = ww/s, wh/s
+	}
+	logical := C.sfFloatRect{
+		width:  C.float(lw),
+		height: C.float(lh),
+	}
+	C.sfView_reset(win.scale.view, logical)
+	viewport := C.sfFloatRect{
+		left:   C.float(vx / ww),
+		top:    C.float(vy / wh),
+		width:  C.float(vpw / ww),
+		height: C.float(vph / wh),
+	}
+	C.sfView_setViewport(win.scale.view, viewport)
+	C.sfRenderWindow_setView(win.win, win.scale.view)
+}
+
+// defaultView returns the default view of the window; i.e. the view of its
+// scaling policy if set, and the default SFML view otherwise.
+func (win *Window) defaultView() *C.sfView {
+	if win.scale.view != nil {
+		return win.scale.view
+	}
+	return C.sfRenderWindow_getDefaultView(win.win)
+}
+
+// virtualPt returns the virtual coordinates of the provided pixel position of
+// the window, as specified by its scaling policy.
+func (win *Window) virtualPt(pt image.Point) image.Point {
+	if win.scale.view == nil {
+		return pt
+	}
+	coordPos := C.sfRenderWindow_mapPixelToCoords(win.win, sfmlIntPt(pt), win.scale.view)
+	return image.Pt(int(math.Round(float64(coordPos.x))), int(math.Round(float64(coordPos.y))))
+}
+
+// pixelPt returns the pixel position of the provided virtual coordinates of the
+// window, as specified by its scaling policy.
+func (win *Window) pixelPt(pt image.Point) image.Point {
+	if win.scale.view == nil {
+		return pt
+	}
+	pixelPos := C.sfRenderWindow_mapCoordsToPixel(win.win, sfmlFloatPt(pt), win.scale.view)
+	return image.Pt(int(pixelPos.x), int(pixelPos.y))
+}
+
+// fillScaled fills the virtual area of the window with the provided color, and
+// the remaining area of the window with black bars.
+func (win *Window) fillScaled(c color.Color) {
+	C.sfRenderWindow_clear(win.win, sfmlColor(color.Black))
+	C.sfRectangleShape_setFillColor(win.scale.rect, sfmlColor(c))
+	// Draw the virtual area using the view of the scaling policy and restore the
+	// current view afterwards.
+	view := C.sfView_copy(C.sfRenderWindow_getView(win.win))
+	defer C.sfView_destroy(view)
+	C.sfRenderWindow_setView(win.win, win.scale.view)
+	C.sfRenderWindow_drawRectangleShape(win.win, win.scale.rect, nil)
+	C.sfRenderWindow_setView(win.win, view)
+}
+
+// freeScaler frees the resources of the scaling policy of the window.
+func (win *Window) freeScaler() {
+	if win.scale.view == nil {
+		return
+	}
+	C.sfRectangleShape_destroy(win.scale.rect)
+	C.sfView_destroy(win.scale.view)
+}
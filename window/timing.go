@@ -0,0 +1,101 @@
+package window
+
+// #include <SFML/Graphics.h>
+import "C"
+
+import (
+	"time"
+)
+
+// nrecent specifies the number of recent frames used to calculate the rolling
+// frame timing statistics.
+const nrecent = 120
+
+// FrameStats represents the frame timing statistics of a window, as measured
+// between consecutive calls to Display.
+type FrameStats struct {
+	// Total number of timed frames; i.e. every displayed frame but the first.
+	Frames int
+	// Duration of the last frame.
+	Last time.Duration
+	// Average number of frames per second over the recent frames.
+	FPS float64
+	// Average duration of the recent frames.
+	Avg time.Duration
+	// Duration of the longest recent frame.
+	Worst time.Duration
+}
+
+// FrameStats returns the frame timing statistics of the window.
+func (win *Window) FrameStats() FrameStats {
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	return win.timer.stats()
+}
+
+// SetVSync enables or disables vertical synchronization. It is disabled by
+// default.
+//
+// Note: Vertical synchronization should not be combined with SetFramerateLimit.
+func (win *Window) SetVSync(enabled bool) {
+	win.vsync = enabled
+	win.lock()
+	defer win.unlock()
+	C.sfRenderWindow_setVerticalSyncEnabled(win.win, sfmlBool(enabled))
+}
+
+// SetFramerateLimit limits the number of frames per second by sleeping in
+// Display as required. A limit of 0 disables the framerate limit, which is the
+// default.
+func (win *Window) SetFramerateLimit(fps int) {
+	win.fpsLimit = fps
+	win.lock()
+	defer win.unlock()
+	C.sfRenderWindow_setFramerateLimit(win.win, C.uint(fps))
+}
+
+// frameTimer records the durations of recently displayed frames.
+type frameTimer struct {
+	// Time of the last call to Display; or zero if no frame has been displayed.
+	last time.Time
+	// Durations of the recent frames, stored in a ring buffer.
+	recent [nrecent]time.Duration
+	// Number of timed frames.
+	frames int
+}
+
+// tick records the display of a frame at time t.
+func (timer *frameTimer) tick(t time.Time) {
+	if !timer.last.IsZero() {
+		timer.recent[timer.frames%nrecent] = t.Sub(timer.last)
+		timer.frames++
+	}
+	timer.last = t
+}
+
+// stats returns the frame timing statistics of the recent frames.
+func (timer *frameTimer) stats() FrameStats {
+	stats := FrameStats{
+		Frames: timer.frames,
+	}
+	n := timer.frames
+	if n == 0 {
+		return stats
+	}
+	stats.Last = timer.recent[(n-1)%nrecent]
+	if n > nrecent {
+		n = nrecent
+	}
+	var total time.Duration
+	for _, d := range timer.recent[:n] {
+		total += d
+		if d > stats.Worst {
+			stats.Worst = d
+		}
+	}
+	stats.Avg = total / time.Duration(n)
+	if stats.Avg > 0 {
+		stats.FPS = float64(time.Second) / float64(stats.Avg)
+	}
+	return stats
+}
@@ -0,0 +1,65 @@
+package window
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrameTimer(t *testing.T) {
+	ms := time.Millisecond
+	golden := []struct {
+		// Durations of the displayed frames.
+		durs []time.Duration
+		want FrameStats
+	}{
+		// No frames.
+		{
+			durs: nil,
+			want: FrameStats{},
+		},
+		// Fewer than nrecent frames.
+		{
+			durs: []time.Duration{10 * ms, 30 * ms, 20 * ms},
+			want: FrameStats{Frames: 3, Last: 20 * ms, FPS: 50, Avg: 20 * ms, Worst: 30 * ms},
+		},
+		// Exactly nrecent frames.
+		{
+			durs: repeat(nrecent, 25*ms),
+			want: FrameStats{Frames: nrecent, Last: 25 * ms, FPS: 40, Avg: 25 * ms, Worst: 25 * ms},
+		},
+		// Wraparound of the ring buffer; the slow frames fall out of the recent
+		// frames.
+		{
+			durs: append(repeat(10, 100*ms), repeat(nrecent, 10*ms)...),
+			want: FrameStats{Frames: nrecent + 10, Last: 10 * ms, FPS: 100, Avg: 10 * ms, Worst: 10 * ms},
+		},
+		// Wraparound of the ring buffer, with a slow recent frame.
+		{
+			durs: append(repeat(nrecent+5, 10*ms), 250*ms),
+			want: FrameStats{Frames: nrecent + 6, Last: 250 * ms, FPS: float64(time.Second) / float64(12*ms), Avg: 12 * ms, Worst: 250 * ms},
+		},
+	}
+	for i, g := range golden {
+		var timer frameTimer
+		// The first frame is not timed.
+		now := time.Unix(0, 0)
+		timer.tick(now)
+		for _, d := range g.durs {
+			now = now.Add(d)
+			timer.tick(now)
+		}
+		got := timer.stats()
+		if got != g.want {
+			t.Errorf("i=%d: stats mismatch; expected %+v, got %+v", i, g.want, got)
+		}
+	}
+}
+
+// repeat returns a slice of n frame durations d.
+func repeat(n int, d time.Duration) []time.Duration {
+	durs := make([]time.Duration, n)
+	for i := range durs {
+		durs[i] = d
+	}
+	return durs
+}
@@ -0,0 +1,66 @@
+package window
+
+// #include <SFML/Window.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// A VideoMode specifies the dimensions and pixel depth of a window or screen.
+type VideoMode struct {
+	// Width in pixels.
+	Width int
+	// Height in pixels.
+	Height int
+	// Pixel depth in bits per pixel.
+	BitsPerPixel int
+}
+
+// DesktopMode returns the current video mode of the desktop.
+func DesktopMode() VideoMode {
+	return goVideoMode(C.sfVideoMode_getDesktopMode())
+}
+
+// FullscreenModes returns the video modes supported in full screen mode,
+// sorted from best to worst.
+func FullscreenModes() []VideoMode {
+	var n C.size_t
+	first := C.sfVideoMode_getFullscreenModes(&n)
+	if first == nil || n == 0 {
+		return nil
+	}
+	sfModes := (*[1 << 20]C.sfVideoMode)(unsafe.Pointer(first))[:n:n]
+	modes := make([]VideoMode, len(sfModes))
+	for i, sfMode := range sfModes {
+		modes[i] = goVideoMode(sfMode)
+	}
+	return modes
+}
+
+// IsValid reports whether the video mode is supported in full screen mode;
+// i.e. whether it is listed by FullscreenModes. The check does not apply to
+// windowed mode, in which windows of any size may be opened.
+func (mode VideoMode) IsValid() bool {
+	return C.sfVideoMode_isValid(sfmlVideoMode(mode)) == C.sfTrue
+}
+
+// sfmlVideoMode returns a SFML VideoMode based on the provided Go VideoMode.
+func sfmlVideoMode(mode VideoMode) C.sfVideoMode {
+	sfMode := C.sfVideoMode{
+		width:        C.uint(mode.Width),
+		height:       C.uint(mode.Height),
+		bitsPerPixel: C.uint(mode.BitsPerPixel),
+	}
+	return sfMode
+}
+
+// goVideoMode returns a Go VideoMode based on the provided SFML VideoMode.
+func goVideoMode(sfMode C.sfVideoMode) VideoMode {
+	mode := VideoMode{
+		Width:        int(sfMode.width),
+		Height:       int(sfMode.height),
+		BitsPerPixel: int(sfMode.bitsPerPixel),
+	}
+	return mode
+}
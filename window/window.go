@@ -10,26 +10,47 @@ package window
 import "C"
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"math"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/mewspring/sfml/font"
+	"github.com/mewspring/sfml/internal/drawopt"
+	"github.com/mewspring/sfml/internal/glctx"
+	"github.com/mewspring/sfml/mainthread"
 	"github.com/mewspring/sfml/texture"
+	"github.com/mewspring/sfml/view"
 	"github.com/mewspring/wandi"
+	"github.com/mewspring/we"
 )
 
-// Style specifies the style and behavior of windows.
-type Style int
+// Style is a bitfield which specifies the style and behavior of windows.
+type Style uint32
 
 // Window styles.
 const (
-	// Fixed states that the window cannot be resized.
-	Fixed Style = iota
+	// Borderless states that the window has no decorations; i.e. no titlebar
+	// and no border.
+	Borderless Style = C.sfNone
+	// Titlebar states that the window has a titlebar.
+	Titlebar Style = C.sfTitlebar
+	// Resizable states that the window can be resized and has a maximize
+	// button.
+	Resizable Style = C.sfResize
+	// Closable states that the window has a close button.
+	Closable Style = C.sfClose
 	// FullScreen states that the window is in full screen mode.
-	FullScreen
+	FullScreen Style = C.sfFullscreen
+	// Default states that the window has a titlebar, can be resized and has a
+	// close button.
+	Default Style = C.sfDefaultStyle
+	// Fixed states that the window cannot be resized.
+	Fixed Style = Default &^ Resizable
 )
 
 // A Window represents a graphical window capable of handling draw operations
@@ -37,78 +58,250 @@ const (
 type Window struct {
 	// A renderable window.
 	win *C.sfRenderWindow
+	// OpenGL context of the window.
+	ctx *glctx.Context
+	// Title of the window.
+	title string
+	// Style of the window.
+	style Style
+	// Video mode of the window.
+	mode VideoMode
+	// Requested context settings of the window; or nil if the driver defaults
+	// are used.
+	settings *C.sfContextSettings
+	// Style, video mode and position of the window before entering full screen
+	// mode; or nil if in windowed mode.
+	windowed *windowedState
+	// Specifies whether the mouse cursor is hidden.
+	cursorHidden bool
+	// Specifies whether the mouse cursor is grabbed.
+	cursorGrabbed bool
+	// Specifies whether vertical synchronization is enabled.
+	vsync bool
+	// Framerate limit of the window; or 0 if unlimited.
+	fpsLimit int
+	// Frame timing of the window.
+	timer frameTimer
+	// Specifies whether the window is hidden.
+	hidden bool
+	// Specifies whether key repeat is disabled.
+	noKeyRepeat bool
+	// Joystick threshold of the window; or 0 if the default is used.
+	joyThreshold float64
+	// Icon of the window; or nil if the default icon is used.
+	icon *image.NRGBA
+	// Mouse cursor of the window; or nil if the default cursor is used.
+	cursor *Cursor
+	// Previously recorded cursor position within the window.
+	prev image.Point
+	// Mouse buttons held down within the window, in the order they were
+	// pressed.
+	held []we.Button
+	// Scaling policy of the window.
+	scale scaler
+	// Protects closed, timer, frame, rec and play, which are accessed by both
+	// the goroutine which renders to the window and other goroutines.
+	recMu sync.Mutex
+	// Specifies whether the native window is destroyed, either by Close or on
+	// a failure to recreate it.
+	closed bool
+	// Number of frames displayed by the window.
+	frame uint64
+	// Event recorder of the window; or nil if not recording.
+	rec *recorder
+	// Event replayer of the window; or nil if not replaying.
+	play *replayer
+	// Function called before each frame is displayed; or nil.
+	onDisplay func()
 }
 
-// Open opens a new window of the specified dimensions. An optional window style
-// may be provided. By default the window is resizeable and not in full screen
-// mode.
+// Open opens a new window of the specified dimensions. The initial title,
+// position, style, video mode and context settings of the window can be
+// customized through the provided options; see WithTitle, WithPos, WithStyle,
+// WithVideoMode and WithContextSettings.
+//
+// The default title of the window is "untitled", its position is decided by
+// the window manager, its style is Default and its pixel depth is 32 bits per
+// pixel. The context settings of the window default to those of the driver;
+// use the Settings method to query the settings actually granted.
 //
-// Note: The main thread must be used for both window creation and event
-// handling. It is perfectly fine to use separate threads for rendering and
-// event handling, as long as all event handling takes place in the main thread.
+// An error is returned if a FullScreen window is requested with a video mode
+// not listed by FullscreenModes.
+//
+// Note: Window creation takes place on the main thread, as required by some
+// operating systems. Programs which open windows from other goroutines than the
+// main goroutine must be started using mainthread.Run; while Run is not active,
+// the window is created on the thread of the calling goroutine, which fails on
+// e.g. macOS unless it is the main thread.
 //
 // Note: The Close method of the window must be called when finished using it.
-func Open(width, height int, style ...Style) (*Window, error) {
-	if len(style) > 1 {
-		return nil, fmt.Errorf("window.Open: invalid number of optional window styles; expected zero or one, got %d", len(style))
-	}
-
-	// Open a new window of the specified dimensions.
-	mode := C.sfVideoMode{
-		width:        C.uint(width),
-		height:       C.uint(height),
-		bitsPerPixel: 32,
-	}
-	title := C.CString("untitled")
-	sfStyle := C.sfUint32(C.sfDefaultStyle)
-	if len(style) > 0 {
-		switch style[0] {
-		case Fixed:
-			sfStyle &^= C.sfResize
-		case FullScreen:
-			sfStyle = C.sfFullscreen
-		}
-	}
-	w := C.sfRenderWindow_create(mode, title, sfStyle, nil)
-	win := &Window{
-		win: w,
+func Open(width, height int, opts ...Option) (win *Window, err error) {
+	mainthread.Call(func() {
+		win, err = open(width, height, opts...)
+	})
+	return win, err
+}
+
+// open opens a new window of the specified dimensions, customized through the
+// provided options.
+func open(width, height int, opts ...Option) (*Window, error) {
+	conf := &config{
+		title: "untitled",
+		style: Default,
+	}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	title, style, pos, settings := conf.title, conf.style, conf.pos, conf.settings
+	mode := VideoMode{
+		Width:        width,
+		Height:       height,
+		BitsPerPixel: 32,
+	}
+	if conf.mode != nil {
+		mode = *conf.mode
+	}
+	if style&FullScreen != 0 && !mode.IsValid() {
+		return nil, fmt.Errorf("window.Open: invalid full screen video mode %dx%d (%d bpp)", mode.Width, mode.Height, mode.BitsPerPixel)
 	}
 
-	// TODO(u): Decide if vsync should be enabled by default.
+	// Open a new window of the specified video mode.
+	glctx.Lock()
+	w := C.sfRenderWindow_createUnicode(sfmlVideoMode(mode), utf32(title), C.sfUint32(style), settings)
+	if w == nil {
+		glctx.Unlock()
+		return nil, fmt.Errorf("window.Open: unable to create %dx%d window", mode.Width, mode.Height)
+	}
+	// Deactivate the OpenGL context of the window, which is activated on
+	// creation. Whichever goroutine that ends up rendering to the window will
+	// activate the OpenGL context when required.
+	C.sfRenderWindow_setActive(w, C.sfFalse)
+	glctx.Unlock()
+	win := &Window{
+		win:      w,
+		title:    title,
+		style:    style,
+		mode:     mode,
+		settings: settings,
+	}
+	win.ctx = glctx.NewContext(func(active bool) {
+		C.sfRenderWindow_setActive(win.win, sfmlBool(active))
+	})
+	if pos != nil {
+		win.SetPos(*pos)
+	}
 
-	// Enable vsync.
-	//C.sfRenderWindow_setVerticalSyncEnabled(sfWin.Win, C.sfTrue)
+	return win, nil
+}
 
-	// TODO(u): Evaluate the effect of deactivating the OpenGL context of the
-	// current thread.
+// Settings returns the context settings of the window, as granted by the
+// driver. They may differ from the requested context settings.
+func (win *Window) Settings() ContextSettings {
+	return goContextSettings(C.sfRenderWindow_getSettings(win.win))
+}
 
-	// Deactivate the OpenGL context of the current thread. Whichever thread that
-	// ends up being the rendering thread will activate the OpenGL context
-	// implicitly through a call to window.display().
-	//C.sfRenderWindow_setActive(C.sfFalse)
+// Close closes the window. The frame rendered by the calling goroutine, if any,
+// ends. Closing a closed window is a no-op.
+func (win *Window) Close() {
+	glctx.EndFrame()
+	if win.isClosed() {
+		return
+	}
+	mainthread.Call(func() {
+		// Wait for other goroutines to finish rendering to the window.
+		win.ctx.Lock()
+		win.ctx.Release()
+		glctx.Lock()
+		defer glctx.Unlock()
+		win.freeScaler()
+		C.sfRenderWindow_close(win.win)
+		C.sfRenderWindow_destroy(win.win)
+		win.setClosed()
+	})
+}
 
-	return win, nil
+// isClosed reports whether the native window of the window is destroyed.
+func (win *Window) isClosed() bool {
+	win.recMu.Lock()
+	defer win.recMu.Unlock()
+	return win.closed
 }
 
-// Close closes the window.
-func (win *Window) Close() {
-	C.sfRenderWindow_close(win.win)
-	C.sfRenderWindow_destroy(win.win)
+// setClosed marks the native window of the window as destroyed.
+func (win *Window) setClosed() {
+	win.recMu.Lock()
+	win.closed = true
+	win.recMu.Unlock()
 }
 
 // SetTitle sets the title of the window.
 //
 // Note: The title will be updated on the next call to PollEvent.
 func (win *Window) SetTitle(title string) {
+	win.title = title
 	C.sfRenderWindow_setUnicodeTitle(win.win, utf32(title))
 }
 
 // ShowCursor displays or hides the mouse cursor depending on the value of
 // visible. It is visible by default.
 func (win *Window) ShowCursor(visible bool) {
+	win.cursorHidden = !visible
 	C.sfRenderWindow_setMouseCursorVisible(win.win, sfmlBool(visible))
 }
 
+// Pos returns the position of the window on the desktop.
+func (win *Window) Pos() image.Point {
+	pos := C.sfRenderWindow_getPosition(win.win)
+	return image.Pt(int(pos.x), int(pos.y))
+}
+
+// SetPos sets the position of the window on the desktop.
+func (win *Window) SetPos(pt image.Point) {
+	C.sfRenderWindow_setPosition(win.win, sfmlIntPt(pt))
+}
+
+// SetSize resizes the window to the specified dimensions.
+func (win *Window) SetSize(width, height int) {
+	size := C.sfVector2u{
+		x: C.uint(width),
+		y: C.uint(height),
+	}
+	C.sfRenderWindow_setSize(win.win, size)
+}
+
+// SetVisible shows or hides the window depending on the value of visible. It
+// is visible by default.
+func (win *Window) SetVisible(visible bool) {
+	win.hidden = !visible
+	C.sfRenderWindow_setVisible(win.win, sfmlBool(visible))
+}
+
+// RequestFocus requests the window to be given input focus. The request may be
+// denied by the operating system.
+func (win *Window) RequestFocus() {
+	C.sfRenderWindow_requestFocus(win.win)
+}
+
+// HasFocus reports whether the window has input focus.
+func (win *Window) HasFocus() bool {
+	return C.sfRenderWindow_hasFocus(win.win) == C.sfTrue
+}
+
+// SetKeyRepeat enables or disables key repeat. When enabled, holding down a
+// key generates repeated KeyPress events. It is enabled by default.
+func (win *Window) SetKeyRepeat(enabled bool) {
+	win.noKeyRepeat = !enabled
+	C.sfRenderWindow_setKeyRepeatEnabled(win.win, sfmlBool(enabled))
+}
+
+// SetJoystickThreshold sets the joystick threshold of the window; i.e. the
+// minimum axis movement, in the range [0, 100], required to generate a joystick
+// move event.
+func (win *Window) SetJoystickThreshold(threshold float64) {
+	win.joyThreshold = threshold
+	C.sfRenderWindow_setJoystickThreshold(win.win, C.float(threshold))
+}
+
 // Width returns the width of the window.
 func (win *Window) Width() int {
 	size := C.sfRenderWindow_getSize(win.win)
@@ -131,72 +324,207 @@ func (win *Window) Draw(dp image.Point, src wandi.Image) error {
 // DrawRect draws a subset of the src image, as defined by the source rectangle
 // sr, onto the window starting at the destination point dp.
 func (win *Window) DrawRect(dp image.Point, src wandi.Image, sr image.Rectangle) error {
+	if err := win.drawRect(dp, src, sr, nil); err != nil {
+		return fmt.Errorf("Window.DrawRect: %v", err)
+	}
+	return nil
+}
+
+// DrawRectOptions draws a subset of the src image, as defined by the source
+// rectangle sr, onto the window at the destination point dp, transformed as
+// specified by the provided draw options. A nil opts is equivalent to DrawRect.
+func (win *Window) DrawRectOptions(dp image.Point, src wandi.Image, sr image.Rectangle, opts *texture.DrawOptions) error {
+	if err := win.drawRect(dp, src, sr, opts); err != nil {
+		return fmt.Errorf("Window.DrawRectOptions: %v", err)
+	}
+	return nil
+}
+
+// drawRect draws a subset of the src image, as defined by the source rectangle
+// sr, onto the window at the destination point dp, transformed as specified by
+// the provided draw options.
+func (win *Window) drawRect(dp image.Point, src wandi.Image, sr image.Rectangle, opts *texture.DrawOptions) error {
+	if win.isClosed() {
+		return errors.New("window closed")
+	}
+	glctx.BeginFrame()
+	win.lock()
+	defer win.unlock()
+	states := (*C.sfRenderStates)(drawopt.RenderStates(opts))
 	switch srcImg := src.(type) {
 	case *texture.Drawable:
 		sprite := drawableSprite(srcImg)
-		C.sfSprite_setTextureRect(sprite, sfmlIntRect(sr))
-		C.sfSprite_setPosition(sprite, sfmlFloatPt(dp))
-		C.sfRenderWindow_drawSprite(win.win, sprite, nil)
+		drawopt.SetSprite(unsafe.Pointer(sprite), dp, sr, opts)
+		C.sfRenderWindow_drawSprite(win.win, sprite, states)
+		drawopt.ResetSprite(unsafe.Pointer(sprite))
 	case *texture.Image:
 		sprite := imageSprite(srcImg)
-		C.sfSprite_setTextureRect(sprite, sfmlIntRect(sr))
-		C.sfSprite_setPosition(sprite, sfmlFloatPt(dp))
-		C.sfRenderWindow_drawSprite(win.win, sprite, nil)
+		drawopt.SetSprite(unsafe.Pointer(sprite), dp, sr, opts)
+		C.sfRenderWindow_drawSprite(win.win, sprite, states)
+		drawopt.ResetSprite(unsafe.Pointer(sprite))
 	case *font.Text:
 		// TODO(u): Handle sr?
 		text := textText(srcImg)
-		C.sfText_setPosition(text, sfmlFloatPt(dp))
-		C.sfRenderWindow_drawText(win.win, text, nil)
+		orig := drawopt.SetText(unsafe.Pointer(text), dp, opts)
+		C.sfRenderWindow_drawText(win.win, text, states)
+		drawopt.ResetText(unsafe.Pointer(text), orig)
 	default:
-		return fmt.Errorf("Window.DrawRect: support for image format %T not yet implemented", src)
+		return fmt.Errorf("support for image format %T not yet implemented", src)
 	}
 
 	return nil
 }
 
 // Fill fills the entire window with the provided color.
+//
+// When the scaling policy of the window is Letterbox or IntegerScale, only the
+// virtual area is filled and the remaining area is filled with black bars.
 func (win *Window) Fill(c color.Color) {
-	C.sfRenderWindow_clear(win.win, sfmlColor(c))
+	glctx.BeginFrame()
+	win.lock()
+	defer win.unlock()
+	switch win.scale.mode {
+	case Letterbox, IntegerScale:
+		win.fillScaled(c)
+	default:
+		C.sfRenderWindow_clear(win.win, sfmlColor(c))
+	}
 }
 
-// SetActive activates the CPU context of the window.
+// SetActive activates the OpenGL context of the window in the calling
+// goroutine, e.g. to issue custom OpenGL calls. The context remains active, and
+// the goroutine locked to its OS thread, until the frame is displayed.
 func (win *Window) SetActive() {
-	C.sfRenderWindow_setActive(win.win, C.sfTrue)
+	glctx.BeginFrame()
+	win.lock()
+	win.unlock()
 }
 
-// Display displays what has been rendered so far to the window.
+// lock locks the calling goroutine to its OS thread and acquires exclusive
+// access to the OpenGL context of the window, which is activated. Rendering to
+// the window from several goroutines is thereby safe.
+//
+// A goroutine which renders a frame to the window keeps the OpenGL context
+// active until the frame is displayed, or until the goroutine renders to
+// another target. Other goroutines wait for the frame to end.
+func (win *Window) lock() {
+	win.ctx.Lock()
+}
+
+// unlock releases exclusive access to the OpenGL context of the window, which
+// is deactivated unless the calling goroutine renders a frame.
+func (win *Window) unlock() {
+	win.ctx.Unlock()
+}
+
+// Display displays what has been rendered so far to the window, and ends the
+// frame rendered by the calling goroutine.
+//
+// The frame timing statistics of the window are updated on each call, see
+// FrameStats. Display is a no-op once the window is closed.
 func (win *Window) Display() {
+	if win.isClosed() {
+		glctx.EndFrame()
+		return
+	}
+	if win.onDisplay != nil {
+		win.onDisplay()
+	}
+	win.lock()
 	C.sfRenderWindow_display(win.win)
+	win.unlock()
+	glctx.EndFrame()
+	now := time.Now()
+	win.recMu.Lock()
+	win.timer.tick(now)
+	win.frame++
+	win.recMu.Unlock()
+}
+
+// OnDisplay sets a function which is called by Display before each frame is
+// displayed, while the contents of the frame may still be captured; e.g. to
+// record the frames of the window. A nil function removes the current one.
+func (win *Window) OnDisplay(f func()) {
+	win.onDisplay = f
+}
+
+// SetView sets the view of the window, which defines the region of the world
+// shown by subsequent draw operations. A nil view resets the window to its
+// default view, which is based on the scaling policy of the window if set.
+//
+// Note: The window stores a copy of the view. After modifying the view, it must
+// be set again for the changes to take effect.
+func (win *Window) SetView(v *view.View) {
+	if v == nil {
+		C.sfRenderWindow_setView(win.win, win.defaultView())
+		return
+	}
+	C.sfRenderWindow_setView(win.win, viewView(v))
 }
 
-// CursorPos returns the current cursor position within the given window.
+// MapPixelToCoords converts the provided pixel position of the window to world
+// coordinates, using the provided view. A nil view denotes the current view of
+// the window.
+func (win *Window) MapPixelToCoords(pt image.Point, v *view.View) image.Point {
+	coordPos := C.sfRenderWindow_mapPixelToCoords(win.win, sfmlIntPt(pt), viewView(v))
+	return image.Pt(int(math.Round(float64(coordPos.x))), int(math.Round(float64(coordPos.y))))
+}
+
+// MapCoordsToPixel converts the provided world coordinates to a pixel position
+// of the window, using the provided view. A nil view denotes the current view of
+// the window.
+func (win *Window) MapCoordsToPixel(pt image.Point, v *view.View) image.Point {
+	pixelPos := C.sfRenderWindow_mapCoordsToPixel(win.win, sfmlFloatPt(pt), viewView(v))
+	return image.Pt(int(pixelPos.x), int(pixelPos.y))
+}
+
+// CursorPos returns the current cursor position within the given window, in
+// world coordinates of the current view. If the window has a scaling policy,
+// the position is reported in virtual coordinates instead, as for mouse events;
+// see SetScaling. During a replay, the cursor position of the replayed events
+// is reported.
 func (win *Window) CursorPos() image.Point {
-	// get pixel position (may be different than world pos if window is scaled).
+	win.recMu.Lock()
+	play := win.play
+	var pixelPos image.Point
+	if play != nil {
+		pixelPos = play.cursor
+	}
+	win.recMu.Unlock()
+	if play == nil {
+		pos := C.sfMouse_getPosition((*C.sfWindow)(unsafe.Pointer(win.win)))
+		pixelPos = image.Pt(int(pos.x), int(pos.y))
+	}
+	if win.scale.view != nil {
+		return win.virtualPt(pixelPos)
+	}
+	// convert pixel position to world position.
 	//
 	// e.g. for a window (640x480) scaled to 100%x50% (i.e. 640x240), then if the
 	// cursor is at pixel (10, 10) in the window the returned world position
 	// would be (10, 20) since those would be the world coordinates of what the
 	// mouse cursor actually hovers over.
-	pixelPos := C.sfMouse_getPosition((*C.sfWindow)(unsafe.Pointer(win.win)))
-	// convert pixel position to world position.
-	coordPos := C.sfRenderWindow_mapPixelToCoords(win.win, pixelPos, nil)
-	return image.Pt(int(math.Round(float64(coordPos.x))), int(math.Round(float64(coordPos.y))))
+	return win.MapPixelToCoords(pixelPos, nil)
 }
 
-// SetCursorPos sets the position of the cursor in the given window.
+// SetCursorPos sets the position of the cursor in the given window, in world
+// coordinates of the current view, or in virtual coordinates if the window has
+// a scaling policy.
 func (win *Window) SetCursorPos(pt image.Point) {
-	coordPos := C.sfVector2f{
-		x: C.float(pt.X),
-		y: C.float(pt.Y),
+	var pixelPos image.Point
+	if win.scale.view != nil {
+		pixelPos = win.pixelPt(pt)
+	} else {
+		// convert world position to pixel position.
+		pixelPos = win.MapCoordsToPixel(pt, nil)
 	}
-	// convert world position to pixel position.
-	pixelPos := C.sfRenderWindow_mapCoordsToPixel(win.win, coordPos, nil)
-	C.sfMouse_setPosition(pixelPos, (*C.sfWindow)(unsafe.Pointer(win.win)))
+	C.sfMouse_setPosition(sfmlIntPt(pixelPos), (*C.sfWindow)(unsafe.Pointer(win.win)))
 }
 
 // GrabCursor specifies whether to grab the mouse cursor within the given
 // window.
 func (win *Window) GrabCursor(grab bool) {
+	win.cursorGrabbed = grab
 	grabbed := C.sfFalse
 	if grab {
 		grabbed = C.sfTrue
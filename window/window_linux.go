@@ -1,12 +1,16 @@
 //go:build linux
 
-package main
+package window
 
-// #cgo LDFLAGS: -lX11
 // #include <X11/Xlib.h>
+//
+// #cgo LDFLAGS: -lX11
 import "C"
 
 func init() {
+	// Initialize Xlib support for concurrent threads, so that windows may be
+	// opened and rendered to from several goroutines.
+	//
 	// ref: http://en.sfml-dev.org/forums/index.php?topic=10228.msg100775
 	C.XInitThreads()
 }